@@ -0,0 +1,186 @@
+package azuredevops
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// APIResource identifies a group of endpoints that share an api-version
+type APIResource string
+
+const (
+	// BoardsAPI is used by BoardsService
+	BoardsAPI APIResource = "boards"
+	// BuildDefinitionsAPI is used by BuildDefinitionsService
+	BuildDefinitionsAPI APIResource = "build.definitions"
+	// BuildsAPI is used by BuildsService
+	BuildsAPI APIResource = "build.builds"
+	// BuildFoldersAPI is used by BuildDefinitionsService.ListFolders,
+	// CreateFolder and DeleteFolder
+	BuildFoldersAPI APIResource = "build.folders"
+	// BuildArtifactsAPI is used by BuildsService.ListArtifacts, GetArtifact
+	// and DownloadArtifact
+	BuildArtifactsAPI APIResource = "build.artifacts"
+	// BuildChangesAPI is used by BuildsService.GetChanges and GetWorkItemRefs
+	BuildChangesAPI APIResource = "build.changes"
+	// BuildChangesBetweenAPI is used by BuildsService.GetChangesBetween and
+	// GetWorkItemRefsBetween
+	BuildChangesBetweenAPI APIResource = "build.changesbetween"
+	// BuildLogsAPI is used by BuildsService.ListLogs and GetLog
+	BuildLogsAPI APIResource = "build.logs"
+	// BuildPropertiesAPI is used by BuildsService.GetProperties and
+	// UpdateProperties
+	BuildPropertiesAPI APIResource = "build.properties"
+	// BuildTagsAPI is used by BuildsService.AddTags, RemoveTag and ListTags
+	BuildTagsAPI APIResource = "build.tags"
+	// BuildTimelineAPI is used by BuildsService.GetTimeline
+	BuildTimelineAPI APIResource = "build.timeline"
+	// DeliveryPlansAPI is used by DeliveryPlansService.List
+	DeliveryPlansAPI APIResource = "work.plans"
+	// DeliveryTimelineAPI is used by DeliveryPlansService.GetTimeLine
+	DeliveryTimelineAPI APIResource = "work.deliverytimeline"
+	// GitRefsAPI is used by GitService.ListRefs
+	GitRefsAPI APIResource = "git.refs"
+	// IterationsAPI is used by IterationsService
+	IterationsAPI APIResource = "work.iterations"
+	// IterationWorkItemsAPI is used by WorkItemsService.GetIdsForIteration
+	IterationWorkItemsAPI APIResource = "work.iterationworkitems"
+	// PullRequestsAPI is used by PullRequestsService
+	PullRequestsAPI APIResource = "git.pullrequests"
+	// TeamsAPI is used by TeamsService
+	TeamsAPI APIResource = "core.teams"
+	// TestRunsAPI is used by TestsService.List
+	TestRunsAPI APIResource = "test.runs"
+	// TestResultsAPI is used by TestsService.ResultsList
+	TestResultsAPI APIResource = "test.results"
+	// WorkItemsAPI is used by WorkItemsService.GetForIteration
+	WorkItemsAPI APIResource = "wit.workitems"
+)
+
+// DefaultAPIVersions is the api-version sent for each resource unless the
+// Client overrides it
+var DefaultAPIVersions = map[APIResource]string{
+	BoardsAPI:              "4.1-preview",
+	BuildDefinitionsAPI:    "5.0-preview.6",
+	BuildsAPI:              "4.1",
+	BuildArtifactsAPI:      "4.1",
+	BuildChangesAPI:        "4.1",
+	BuildChangesBetweenAPI: "4.1-preview.2",
+	BuildFoldersAPI:        "5.0-preview.2",
+	BuildLogsAPI:           "4.1",
+	BuildPropertiesAPI:     "4.1-preview.1",
+	BuildTagsAPI:           "4.1",
+	BuildTimelineAPI:       "4.1",
+	DeliveryPlansAPI:       "6.1-preview.1",
+	DeliveryTimelineAPI:    "5.0-preview.1",
+	GitRefsAPI:             "4.1",
+	IterationsAPI:          "4.1-preview",
+	IterationWorkItemsAPI:  "6.1-preview.1",
+	PullRequestsAPI:        "4.1",
+	TeamsAPI:               "6.1-preview.3",
+	TestRunsAPI:            "4.1",
+	TestResultsAPI:         "4.1",
+	WorkItemsAPI:           "6.1-preview.3",
+}
+
+// WithAPIVersions pins the api-version used for the given resources, e.g. to
+// the versions an Azure DevOps Server installation supports
+func WithAPIVersions(versions map[APIResource]string) ClientOption {
+	return func(c *Client) {
+		if c.APIVersions == nil {
+			c.APIVersions = map[APIResource]string{}
+		}
+		for resource, version := range versions {
+			c.APIVersions[resource] = version
+		}
+	}
+}
+
+// WithAPIVersionFallback makes the Client retry a request with an older
+// api-version when the server says the requested one is out of range
+func WithAPIVersionFallback() ClientOption {
+	return func(c *Client) {
+		c.APIVersionFallback = true
+	}
+}
+
+// APIVersion returns the api-version to use for resource
+func (c *Client) APIVersion(resource APIResource) string {
+	if version, ok := c.APIVersions[resource]; ok {
+		return version
+	}
+	return DefaultAPIVersions[resource]
+}
+
+const (
+	versionOutOfRangeTypeKey     = "VssVersionOutOfRangeException"
+	invalidPreviewVersionTypeKey = "VssInvalidPreviewVersionException"
+)
+
+// supportedVersionRe pulls the newest supported version out of the message
+// of a VssVersionOutOfRangeException
+var supportedVersionRe = regexp.MustCompile(`supports is ([0-9]+\.[0-9]+)`)
+
+// IsVersionOutOfRange reports whether err was caused by the server not
+// supporting the requested api-version
+func IsVersionOutOfRange(err error) bool {
+	var e *ErrorResponse
+	return errors.As(err, &e) && e.TypeKey == versionOutOfRangeTypeKey
+}
+
+// fallbackAPIVersion works out the api-version to retry request with after
+// err, returning false if there is nothing sensible to fall back to
+func fallbackAPIVersion(request *http.Request, err error) (string, bool) {
+	var e *ErrorResponse
+	if !errors.As(err, &e) {
+		return "", false
+	}
+
+	current := request.URL.Query().Get("api-version")
+
+	switch e.TypeKey {
+	case versionOutOfRangeTypeKey:
+		match := supportedVersionRe.FindStringSubmatch(e.Message)
+		if match == nil {
+			return "", false
+		}
+		version := match[1]
+		if strings.Contains(current, "-preview") {
+			version += "-preview"
+		}
+		return version, version != current
+	case invalidPreviewVersionTypeKey:
+		if strings.Contains(current, "-preview") {
+			return "", false
+		}
+		return current + "-preview", true
+	}
+
+	return "", false
+}
+
+// withAPIVersion returns a copy of request asking for version, with the
+// body rewound so it can be sent again
+func withAPIVersion(request *http.Request, version string) (*http.Request, error) {
+	clone := request.Clone(request.Context())
+
+	u := *request.URL
+	query := u.Query()
+	query.Set("api-version", version)
+	u.RawQuery = query.Encode()
+	clone.URL = &u
+
+	if request.GetBody != nil {
+		body, err := request.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		clone.Body = body
+	} else if request.Body != nil && request.Body != http.NoBody {
+		return nil, errors.New("request body cannot be replayed")
+	}
+
+	return clone, nil
+}
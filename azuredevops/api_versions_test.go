@@ -0,0 +1,116 @@
+package azuredevops_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/benmatselby/go-azuredevops/azuredevops"
+)
+
+func TestClient_APIVersion(t *testing.T) {
+	c := azuredevops.NewClient(
+		"AZURE_DEVOPS_ACCOUNT", "AZURE_DEVOPS_Project", "AZURE_DEVOPS_TOKEN",
+		azuredevops.WithAPIVersions(map[azuredevops.APIResource]string{azuredevops.BuildsAPI: "5.0"}),
+	)
+
+	if got := c.APIVersion(azuredevops.BuildsAPI); got != "5.0" {
+		t.Fatalf("expected the pinned version 5.0; got %s", got)
+	}
+
+	if got := c.APIVersion(azuredevops.TeamsAPI); got != azuredevops.DefaultAPIVersions[azuredevops.TeamsAPI] {
+		t.Fatalf("expected the default teams version; got %s", got)
+	}
+}
+
+func TestClient_APIVersion_SentWithRequest(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+	c.APIVersions = map[azuredevops.APIResource]string{azuredevops.TeamsAPI: "5.1"}
+
+	mux.HandleFunc(teamsListURL, func(w http.ResponseWriter, r *http.Request) {
+		testURL(t, r, "/_apis/teams?api-version=5.1")
+		fmt.Fprint(w, "{}")
+	})
+
+	if _, _, err := c.Teams.List(context.Background(), nil); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+}
+
+func TestClient_APIVersionFallback(t *testing.T) {
+	tt := []struct {
+		name     string
+		fallback bool
+		versions []string
+		wantErr  bool
+	}{
+		{name: "falls back to the supported preview version", fallback: true, versions: []string{"6.1-preview.3", "6.0-preview"}},
+		{name: "does not fall back unless enabled", fallback: false, versions: []string{"6.1-preview.3"}, wantErr: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c, mux, _, teardown := setup()
+			defer teardown()
+			c.APIVersionFallback = tc.fallback
+
+			var versions []string
+			mux.HandleFunc(teamsListURL, func(w http.ResponseWriter, r *http.Request) {
+				version := r.URL.Query().Get("api-version")
+				versions = append(versions, version)
+				if version == "6.1-preview.3" {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprint(w, `{"message": "The requested REST API version of 6.1 is out of range for this server. The latest REST API version this server supports is 6.0.", "typeKey": "VssVersionOutOfRangeException"}`)
+					return
+				}
+				fmt.Fprint(w, `{"value": [{"name": "one"}], "count": 1}`)
+			})
+
+			teams, _, err := c.Teams.List(context.Background(), nil)
+			if tc.wantErr {
+				if !azuredevops.IsVersionOutOfRange(err) {
+					t.Fatalf("expected a version out of range error; got %v", err)
+				}
+			} else if err != nil || len(teams) != 1 {
+				t.Fatalf("expected one team and no error; got %d and %v", len(teams), err)
+			}
+
+			if fmt.Sprint(versions) != fmt.Sprint(tc.versions) {
+				t.Fatalf("expected versions %v; got %v", tc.versions, versions)
+			}
+		})
+	}
+}
+
+func TestClient_APIVersionFallback_Preview(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+	c.APIVersionFallback = true
+	c.APIVersions = map[azuredevops.APIResource]string{azuredevops.BuildsAPI: "7.0"}
+
+	var versions []string
+	mux.HandleFunc(buildListURL, func(w http.ResponseWriter, r *http.Request) {
+		version := r.URL.Query().Get("api-version")
+		versions = append(versions, version)
+		switch version {
+		case "7.0":
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"message": "The requested REST API version of 7.0 is out of range for this server. The latest REST API version this server supports is 5.1.", "typeKey": "VssVersionOutOfRangeException"}`)
+		case "5.1":
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"message": "The requested version \"5.1\" of the resource is under preview.", "typeKey": "VssInvalidPreviewVersionException"}`)
+		default:
+			fmt.Fprint(w, "{}")
+		}
+	})
+
+	if _, _, err := c.Builds.List(context.Background(), nil); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if fmt.Sprint(versions) != "[7.0 5.1 5.1-preview]" {
+		t.Fatalf("expected versions [7.0 5.1 5.1-preview]; got %v", versions)
+	}
+}
@@ -0,0 +1,226 @@
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// azureDevOpsResource is the Azure AD application ID of Azure DevOps,
+	// which tokens must be issued for
+	azureDevOpsResource = "499b84ac-1321-427f-aa17-267ca6975798"
+	// azureADTokenURL is the Azure AD v2 token endpoint for a tenant
+	azureADTokenURL = "https://login.microsoftonline.com/%s/oauth2/v2.0/token"
+	// managedIdentityTokenURL is the Azure instance metadata token endpoint
+	managedIdentityTokenURL = "http://169.254.169.254/metadata/identity/oauth2/token"
+	// tokenRefreshWindow is how long before expiry a token is refreshed
+	tokenRefreshWindow = 5 * time.Minute
+)
+
+// Authenticator adds credentials to a request before it is sent
+type Authenticator interface {
+	Authenticate(ctx context.Context, request *http.Request) error
+}
+
+// WithAuthenticator makes the Client authenticate every request with a,
+// in place of the personal access token passed to NewClient
+func WithAuthenticator(a Authenticator) ClientOption {
+	return func(c *Client) {
+		c.Authenticator = a
+	}
+}
+
+// PersonalAccessToken authenticates with a personal access token
+type PersonalAccessToken string
+
+// Authenticate sets the token as the basic auth password
+func (t PersonalAccessToken) Authenticate(ctx context.Context, request *http.Request) error {
+	request.SetBasicAuth("", string(t))
+	return nil
+}
+
+// BearerToken authenticates with a static OAuth bearer token
+type BearerToken string
+
+// Authenticate sets the Authorization header
+func (t BearerToken) Authenticate(ctx context.Context, request *http.Request) error {
+	request.Header.Set("Authorization", "Bearer "+string(t))
+	return nil
+}
+
+// NewPipelineAuthenticator authenticates as the build service using the
+// System.AccessToken of the running Azure Pipelines job. The pipeline must
+// map it into the SYSTEM_ACCESSTOKEN environment variable
+func NewPipelineAuthenticator() (BearerToken, error) {
+	token := os.Getenv("SYSTEM_ACCESSTOKEN")
+	if token == "" {
+		return "", errors.New("SYSTEM_ACCESSTOKEN is not set, map System.AccessToken into the environment of the step")
+	}
+	return BearerToken(token), nil
+}
+
+// Token is an OAuth access token and when it expires
+type Token struct {
+	AccessToken string
+	Expiry      time.Time
+}
+
+// valid reports whether t can still be used, leaving time to refresh it
+func (t *Token) valid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	return t.Expiry.IsZero() || time.Now().Add(tokenRefreshWindow).Before(t.Expiry)
+}
+
+// TokenSource fetches a new access token
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+// TokenAuthenticator authenticates with bearer tokens from a TokenSource,
+// caching each token and fetching a new one shortly before it expires
+type TokenAuthenticator struct {
+	source TokenSource
+
+	mu    sync.Mutex
+	token *Token
+}
+
+// NewTokenAuthenticator returns a TokenAuthenticator for source
+func NewTokenAuthenticator(source TokenSource) *TokenAuthenticator {
+	return &TokenAuthenticator{source: source}
+}
+
+// Authenticate sets the Authorization header, refreshing the token if needed
+func (a *TokenAuthenticator) Authenticate(ctx context.Context, request *http.Request) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.token.valid() {
+		token, err := a.source.Token(ctx)
+		if err != nil {
+			return err
+		}
+		a.token = token
+	}
+
+	request.Header.Set("Authorization", "Bearer "+a.token.AccessToken)
+	return nil
+}
+
+// ClientCredentials fetches tokens for an Azure AD service principal using
+// the OAuth client credentials flow
+type ClientCredentials struct {
+	TenantID     string
+	ClientID     string
+	ClientSecret string
+	// TokenURL overrides the Azure AD token endpoint, e.g. for local testing
+	TokenURL string
+	// HTTPClient is used to call the token endpoint, http.DefaultClient if nil
+	HTTPClient *http.Client
+}
+
+// Token requests a new access token from Azure AD
+func (c *ClientCredentials) Token(ctx context.Context) (*Token, error) {
+	tokenURL := c.TokenURL
+	if tokenURL == "" {
+		tokenURL = fmt.Sprintf(azureADTokenURL, url.PathEscape(c.TenantID))
+	}
+
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {c.ClientID},
+		"client_secret": {c.ClientSecret},
+		"scope":         {azureDevOpsResource + "/.default"},
+	}
+
+	request, err := http.NewRequestWithContext(ctx, "POST", tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return fetchToken(c.HTTPClient, request)
+}
+
+// ManagedIdentity fetches tokens for the Azure managed identity of the
+// machine the code is running on
+type ManagedIdentity struct {
+	// ClientID selects a user assigned identity, leave empty for the system
+	// assigned identity
+	ClientID string
+	// Endpoint overrides the instance metadata token endpoint
+	Endpoint string
+	// HTTPClient is used to call the endpoint, http.DefaultClient if nil
+	HTTPClient *http.Client
+}
+
+// Token requests a new access token from the instance metadata service
+func (m *ManagedIdentity) Token(ctx context.Context) (*Token, error) {
+	endpoint := m.Endpoint
+	if endpoint == "" {
+		endpoint = managedIdentityTokenURL
+	}
+
+	query := url.Values{
+		"api-version": {"2018-02-01"},
+		"resource":    {azureDevOpsResource},
+	}
+	if m.ClientID != "" {
+		query.Set("client_id", m.ClientID)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, "GET", endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Metadata", "true")
+
+	return fetchToken(m.HTTPClient, request)
+}
+
+// tokenResponse is the token endpoint response. Azure AD returns expires_in
+// as a number, the metadata service returns it as a string
+type tokenResponse struct {
+	AccessToken string      `json:"access_token"`
+	ExpiresIn   json.Number `json:"expires_in"`
+	Error       string      `json:"error"`
+	Description string      `json:"error_description"`
+}
+
+// fetchToken sends request to a token endpoint and decodes the token
+func fetchToken(client *http.Client, request *http.Request) (*Token, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var body tokenResponse
+	if err := json.NewDecoder(response.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("Decoding token response from %s failed: %v", request.URL.Host, err)
+	}
+
+	if response.StatusCode != http.StatusOK || body.AccessToken == "" {
+		return nil, fmt.Errorf("Token request to %s responded with status %d: %s %s", request.URL.Host, response.StatusCode, body.Error, body.Description)
+	}
+
+	token := &Token{AccessToken: body.AccessToken}
+	if seconds, err := body.ExpiresIn.Int64(); err == nil && seconds > 0 {
+		token.Expiry = time.Now().Add(time.Duration(seconds) * time.Second)
+	}
+	return token, nil
+}
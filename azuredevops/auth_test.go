@@ -0,0 +1,157 @@
+package azuredevops_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/benmatselby/go-azuredevops/azuredevops"
+)
+
+func TestClient_Authenticators(t *testing.T) {
+	tt := []struct {
+		name          string
+		authenticator azuredevops.Authenticator
+		header        string
+	}{
+		{name: "defaults to the personal access token", authenticator: nil, header: "Basic OkFaVVJFX0RFVk9QU19UT0tFTg=="},
+		{name: "personal access token", authenticator: azuredevops.PersonalAccessToken("pat"), header: "Basic OnBhdA=="},
+		{name: "bearer token", authenticator: azuredevops.BearerToken("oauth"), header: "Bearer oauth"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c, mux, _, teardown := setup()
+			defer teardown()
+			c.Authenticator = tc.authenticator
+
+			mux.HandleFunc(teamsListURL, func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Authorization"); got != tc.header {
+					t.Fatalf("expected Authorization %s; got %s", tc.header, got)
+				}
+				fmt.Fprint(w, "{}")
+			})
+
+			if _, _, err := c.Teams.List(context.Background(), nil); err != nil {
+				t.Fatalf("returned error: %v", err)
+			}
+		})
+	}
+}
+
+func TestNewPipelineAuthenticator(t *testing.T) {
+	t.Setenv("SYSTEM_ACCESSTOKEN", "")
+	if _, err := azuredevops.NewPipelineAuthenticator(); err == nil {
+		t.Fatalf("expected an error when SYSTEM_ACCESSTOKEN is not set")
+	}
+
+	t.Setenv("SYSTEM_ACCESSTOKEN", "pipeline-token")
+	a, err := azuredevops.NewPipelineAuthenticator()
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if a != azuredevops.BearerToken("pipeline-token") {
+		t.Fatalf("expected the pipeline token; got %s", a)
+	}
+}
+
+func TestClientCredentials(t *testing.T) {
+	issued := 0
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("returned error: %v", err)
+		}
+		if r.Form.Get("grant_type") != "client_credentials" || r.Form.Get("client_id") != "app" || r.Form.Get("client_secret") != "secret" {
+			t.Fatalf("unexpected token request %v", r.Form)
+		}
+		if r.Form.Get("scope") != "499b84ac-1321-427f-aa17-267ca6975798/.default" {
+			t.Fatalf("unexpected scope %s", r.Form.Get("scope"))
+		}
+		issued++
+		// An expiry inside the refresh window forces a new token each time
+		expiresIn := 3600
+		if issued == 1 {
+			expiresIn = 60
+		}
+		fmt.Fprintf(w, `{"token_type": "Bearer", "expires_in": %d, "access_token": "token-%d"}`, expiresIn, issued)
+	}))
+	defer tokenServer.Close()
+
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	c.Authenticator = azuredevops.NewTokenAuthenticator(&azuredevops.ClientCredentials{
+		TenantID:     "tenant",
+		ClientID:     "app",
+		ClientSecret: "secret",
+		TokenURL:     tokenServer.URL,
+	})
+
+	var headers []string
+	mux.HandleFunc(teamsListURL, func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Get("Authorization"))
+		fmt.Fprint(w, "{}")
+	})
+
+	for i := 0; i < 3; i++ {
+		if _, _, err := c.Teams.List(context.Background(), nil); err != nil {
+			t.Fatalf("returned error: %v", err)
+		}
+	}
+
+	want := []string{"Bearer token-1", "Bearer token-2", "Bearer token-2"}
+	for i := range want {
+		if headers[i] != want[i] {
+			t.Fatalf("expected request %d to use %s; got %s", i, want[i], headers[i])
+		}
+	}
+
+	if issued != 2 {
+		t.Fatalf("expected 2 tokens to be issued; got %d", issued)
+	}
+}
+
+func TestClientCredentials_Error(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error": "invalid_client", "error_description": "bad secret"}`)
+	}))
+	defer tokenServer.Close()
+
+	source := &azuredevops.ClientCredentials{TenantID: "tenant", ClientID: "app", TokenURL: tokenServer.URL}
+	if _, err := source.Token(context.Background()); err == nil {
+		t.Fatalf("expected an error, did not get one")
+	}
+}
+
+func TestManagedIdentity(t *testing.T) {
+	metadata := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.Header.Get("Metadata") != "true" {
+			t.Fatalf("expected the Metadata header")
+		}
+		if r.URL.Query().Get("resource") != "499b84ac-1321-427f-aa17-267ca6975798" || r.URL.Query().Get("client_id") != "identity" {
+			t.Fatalf("unexpected token request %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"access_token": "mi-token", "expires_in": "3599", "token_type": "Bearer"}`)
+	}))
+	defer metadata.Close()
+
+	source := &azuredevops.ManagedIdentity{ClientID: "identity", Endpoint: metadata.URL}
+	token, err := source.Token(context.Background())
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if token.AccessToken != "mi-token" {
+		t.Fatalf("expected mi-token; got %s", token.AccessToken)
+	}
+
+	if token.Expiry.IsZero() {
+		t.Fatalf("expected the expiry to be set")
+	}
+}
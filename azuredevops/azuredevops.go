@@ -2,7 +2,9 @@ package azuredevops
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -21,12 +23,31 @@ const (
 type Client struct {
 	client *http.Client
 
+	// BaseURL is the organization or collection URL, see NewCollectionClient
 	BaseURL   string
 	UserAgent string
+	// HostURLs overrides the URL of individual hosts, see HostURL
+	HostURLs map[Host]string
 
 	Account   string
 	Project   string
 	AuthToken string
+	// APIVersions overrides DefaultAPIVersions for individual resources
+	APIVersions map[APIResource]string
+	// APIVersionFallback retries requests with an older api-version when
+	// the server does not support the requested one
+	APIVersionFallback bool
+
+	// Authenticator adds credentials to each request. When nil, AuthToken is
+	// sent as a personal access token
+	Authenticator Authenticator
+
+	// Middleware wraps every request sent, see WithMiddleware
+	Middleware []Middleware
+
+	// RetryPolicy controls retrying throttled requests, nil disables it
+	RetryPolicy *RetryPolicy
+	rateLimits  rateLimits
 
 	// Services used to proxy to other API endpoints
 	Boards           *BoardsService
@@ -42,15 +63,32 @@ type Client struct {
 	WorkItems        *WorkItemsService
 }
 
+// ClientOption configures a Client as it is constructed by NewClient
+type ClientOption func(*Client)
+
+// WithHTTPClient makes the Client, and so every service, send requests through
+// hc. Use this to set timeouts, proxies, TLS configuration or to share a
+// transport and its connection pool
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) {
+		c.client = hc
+	}
+}
+
 // NewClient gets a new Azure DevOps Client
-func NewClient(account string, project string, token string) *Client {
+func NewClient(account string, project string, token string, opts ...ClientOption) *Client {
 	c := &Client{
+		client:    &http.Client{},
 		Account:   account,
 		Project:   project,
 		AuthToken: token,
 	}
 	c.BaseURL = fmt.Sprintf(baseURL, account)
 
+	for _, opt := range opts {
+		opt(c)
+	}
+
 	c.Boards = &BoardsService{client: c}
 	c.BuildDefinitions = &BuildDefinitionsService{client: c}
 	c.Builds = &BuildsService{client: c}
@@ -66,10 +104,31 @@ func NewClient(account string, project string, token string) *Client {
 	return c
 }
 
+// HTTPClient returns the http.Client used to send requests. A Client that
+// was not created by NewClient falls back to http.DefaultClient
+func (c *Client) HTTPClient() *http.Client {
+	if c.client == nil {
+		return http.DefaultClient
+	}
+	return c.client
+}
+
+// RateLimit returns the rate limit headers from the most recent response
+func (c *Client) RateLimit() RateLimit {
+	return c.rateLimits.get()
+}
+
 // NewRequest creates an API request where the URL is relative from https://%s.visualstudio.com/%s.
 // Basically this includes the project which is most requests to the API
 func (c *Client) NewRequest(method, URL string, body interface{}) (*http.Request, error) {
-	request, err := c.NewBaseRequest(
+	return c.NewRequestWithContext(context.Background(), method, URL, body)
+}
+
+// NewRequestWithContext is the same as NewRequest, but the returned request
+// is bound to ctx so cancellation and deadlines reach the HTTP call
+func (c *Client) NewRequestWithContext(ctx context.Context, method, URL string, body interface{}) (*http.Request, error) {
+	request, err := c.NewBaseRequestWithContext(
+		ctx,
 		method,
 		fmt.Sprintf("/%s/%s", url.PathEscape(c.Project), URL),
 		body,
@@ -80,6 +139,28 @@ func (c *Client) NewRequest(method, URL string, body interface{}) (*http.Request
 // NewBaseRequest does not take into consideration the project
 // and simply uses the base https://%s.visualstudio.com base URL
 func (c *Client) NewBaseRequest(method, URL string, body interface{}) (*http.Request, error) {
+	return c.NewBaseRequestWithContext(context.Background(), method, URL, body)
+}
+
+// NewBaseRequestWithContext is the same as NewBaseRequest, but the returned
+// request is bound to ctx
+func (c *Client) NewBaseRequestWithContext(ctx context.Context, method, URL string, body interface{}) (*http.Request, error) {
+	return c.NewHostRequestWithContext(ctx, HostCore, method, URL, body)
+}
+
+// NewHostRequestWithContext creates a request relative to the base URL of
+// host, for the APIs that are not served from the main host
+func (c *Client) NewHostRequestWithContext(ctx context.Context, host Host, method, URL string, body interface{}) (*http.Request, error) {
+	return c.newRequest(ctx, method, c.HostURL(host)+URL, body)
+}
+
+// newRequest creates a request for an absolute URL, such as a download URL
+// returned by the API
+func (c *Client) newRequest(ctx context.Context, method, URL string, body interface{}) (*http.Request, error) {
+	if ctx == nil {
+		return nil, errors.New("context must be non-nil")
+	}
+
 	var buf io.ReadWriter
 	if body != nil {
 		buf = new(bytes.Buffer)
@@ -91,7 +172,10 @@ func (c *Client) NewBaseRequest(method, URL string, body interface{}) (*http.Req
 		}
 	}
 
-	request, err := http.NewRequest(method, c.BaseURL+URL, buf)
+	request, err := http.NewRequestWithContext(ctx, method, URL, buf)
+	if err != nil {
+		return nil, err
+	}
 
 	if body != nil {
 		request.Header.Set("Content-Type", "application/json")
@@ -101,31 +185,110 @@ func (c *Client) NewBaseRequest(method, URL string, body interface{}) (*http.Req
 		c.UserAgent = userAgent
 	}
 	request.Header.Set("User-Agent", c.UserAgent)
-	return request, err
+	return request, nil
 }
 
-// Execute runs all the http requests on the API
+// Execute runs all the http requests on the API. The request is sent with
+// whatever context it was created with, see NewRequestWithContext.
+// Any 2xx status is treated as success. The body is decoded into r unless r
+// is nil or the response has no content, as is the case for a 204 from a delete
 func (c *Client) Execute(request *http.Request, r interface{}) (*http.Response, error) {
-	request.SetBasicAuth("", c.AuthToken)
+	return c.withFallback(request, func(request *http.Request) (*http.Response, error) {
+		return c.execute(request, r)
+	})
+}
+
+// executeStream sends request like Execute, but leaves the body of a
+// successful response unread for the caller to read and close
+func (c *Client) executeStream(request *http.Request) (*http.Response, error) {
+	return c.withFallback(request, c.send)
+}
 
-	client := &http.Client{}
-	response, err := client.Do(request)
+// withFallback sends request with send, retrying with an older api-version
+// when APIVersionFallback is set and the server asks for one
+func (c *Client) withFallback(request *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	response, err := send(request)
+
+	// An out of range version can be followed by the older version needing
+	// the -preview flag, so allow for two fallbacks
+	for attempt := 0; err != nil && c.APIVersionFallback && attempt < 2; attempt++ {
+		version, ok := fallbackAPIVersion(request, err)
+		if !ok {
+			break
+		}
+
+		retry, cloneErr := withAPIVersion(request, version)
+		if cloneErr != nil {
+			break
+		}
+
+		request = retry
+		response, err = send(request)
+	}
+
+	return response, err
+}
+
+// execute sends a single request and decodes the response into r
+func (c *Client) execute(request *http.Request, r interface{}) (*http.Response, error) {
+	response, err := c.send(request)
 	if err != nil {
-		return nil, err
+		return response, err
 	}
 	defer response.Body.Close()
 
-	if response.StatusCode != 200 {
-		return nil, fmt.Errorf("Request to %s responded with status %d", request.URL, response.StatusCode)
+	if r == nil || response.StatusCode == http.StatusNoContent {
+		return response, nil
+	}
+
+	if err := json.NewDecoder(response.Body).Decode(r); err != nil && err != io.EOF {
+		return response, fmt.Errorf("Decoding json response from %s failed: %v", request.URL, err)
+	}
+
+	return response, nil
+}
+
+// send authenticates and sends a single request, leaving the body of a
+// successful response open for the caller to read and close. Requests to
+// hosts other than the Client's own are sent without credentials
+func (c *Client) send(request *http.Request) (*http.Response, error) {
+	authenticator := c.Authenticator
+	if authenticator == nil {
+		authenticator = PersonalAccessToken(c.AuthToken)
+	}
+	if c.knownHost(request.URL) {
+		if err := authenticator.Authenticate(request.Context(), request); err != nil {
+			return nil, err
+		}
 	}
 
-	if err := json.NewDecoder(response.Body).Decode(&r); err != nil {
-		return nil, fmt.Errorf("Decoding json response from %s failed: %v", request.URL, err)
+	response, err := c.do(request)
+	if err != nil {
+		// If the context has been cancelled or has expired, that error is
+		// more useful to the caller than the one from the transport
+		if ctxErr := request.Context().Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, err
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		defer response.Body.Close()
+		return response, newErrorResponse(response)
 	}
 
 	return response, nil
 }
 
+// ExecuteWithContext runs the request bound to ctx, replacing any context
+// the request was created with
+func (c *Client) ExecuteWithContext(ctx context.Context, request *http.Request, r interface{}) (*http.Response, error) {
+	if ctx == nil {
+		return nil, errors.New("context must be non-nil")
+	}
+	return c.Execute(request.WithContext(ctx), r)
+}
+
 // addOptions adds the parameters in opt as URL query parameters to s. opt
 // must be a struct whose fields may contain "url" tags.
 // From: https://github.com/google/go-github/blob/master/github/github.go
@@ -152,3 +315,9 @@ func addOptions(s string, opt interface{}) (string, error) {
 	u.RawQuery = qs.Encode()
 	return u.String(), nil
 }
+
+// Bool returns a pointer to v, for optional fields such as
+// BuildUpdate.KeepForever
+func Bool(v bool) *bool {
+	return &v
+}
@@ -1,15 +1,19 @@
 package azuredevops_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
-	"net/url"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/benmatselby/go-azuredevops/azuredevops"
+	"github.com/benmatselby/go-azuredevops/azuredevops/azuredevopstest"
 )
 
 const (
@@ -41,13 +45,50 @@ func setup() (client *azuredevops.Client, mux *http.ServeMux, serverURL string,
 	server := httptest.NewServer(apiHandler)
 
 	// The client being tested and is configured to use test server.
-	client = azuredevops.NewClient("AZURE_DEVOPS_Account", "AZURE_DEVOPS_Project", "AZURE_DEVOPS_TOKEN")
-
-	url, _ := url.Parse(server.URL + baseURLPath)
-	client.BaseURL = url.String()
+	client, err := azuredevops.NewCollectionClient(server.URL+baseURLPath, "AZURE_DEVOPS_Project", "AZURE_DEVOPS_TOKEN")
+	if err != nil {
+		panic(err)
+	}
 	return client, mux, server.URL, server.Close
 }
 
+// cassetteClient returns a Client that replays testdata/cassettes/name.json.
+// To record it again against a real organization set AZURE_DEVOPS_RECORD,
+// AZURE_DEVOPS_ACCOUNT, AZURE_DEVOPS_PROJECT and AZURE_DEVOPS_TOKEN
+func cassetteClient(t *testing.T, name string) *azuredevops.Client {
+	t.Helper()
+
+	mode := azuredevopstest.ModeFromEnv()
+	recorder, err := azuredevopstest.NewRecorder(filepath.Join("testdata", "cassettes", name+".json"), mode)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := recorder.Stop(); err != nil {
+			t.Errorf("saving cassette: %v", err)
+		}
+	})
+
+	token := cassetteEnv("AZURE_DEVOPS_TOKEN", "AZURE_DEVOPS_TOKEN")
+	recorder.Secrets = []string{token}
+
+	return azuredevops.NewClient(
+		cassetteEnv("AZURE_DEVOPS_ACCOUNT", "fabrikam"),
+		cassetteEnv("AZURE_DEVOPS_PROJECT", "Fabrikam-Fiber"),
+		token,
+		azuredevops.WithHTTPClient(recorder.HTTPClient()),
+	)
+}
+
+// cassetteEnv returns the environment variable key when recording, so
+// cassettes can be recorded against real data, otherwise fallback
+func cassetteEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" && azuredevopstest.ModeFromEnv() == azuredevopstest.ModeRecord {
+		return v
+	}
+	return fallback
+}
+
 func testMethod(t *testing.T, r *http.Request, want string) {
 	if got := r.Method; got != want {
 		t.Errorf("Request method: %v, want %v", got, want)
@@ -85,3 +126,159 @@ func Test_NewClient(t *testing.T) {
 		t.Errorf("Client.Token = %s; expected %s", c.AuthToken, "AZURE_DEVOPS_TOKEN")
 	}
 }
+
+func TestClient_NewRequestWithContext_NilContext(t *testing.T) {
+	c := azuredevops.NewClient("AZURE_DEVOPS_ACCOUNT", "AZURE_DEVOPS_Project", "AZURE_DEVOPS_TOKEN")
+
+	var ctx context.Context
+	_, err := c.NewRequestWithContext(ctx, "GET", "_apis/build/builds", nil)
+	if err == nil {
+		t.Fatalf("expected error for nil context, did not get one")
+	}
+}
+
+func TestClient_Execute_CancelledContext(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/AZURE_DEVOPS_Project/_apis/build/builds", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "{}")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := c.Builds.List(ctx, &azuredevops.BuildsListOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestClient_ExecuteWithContext_Deadline(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	done := make(chan struct{})
+	defer close(done)
+
+	mux.HandleFunc("/AZURE_DEVOPS_Project/_apis/build/builds", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	})
+
+	request, err := c.NewRequest("GET", "_apis/build/builds", nil)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = c.ExecuteWithContext(ctx, request, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestClient_Execute_SuccessStatuses(t *testing.T) {
+	tt := []struct {
+		name     string
+		status   int
+		response string
+		target   bool
+		id       int
+	}{
+		{name: "decodes a 201 created body", status: http.StatusCreated, response: `{"id": 42}`, target: true, id: 42},
+		{name: "decodes a 202 accepted body", status: http.StatusAccepted, response: `{"id": 7}`, target: true, id: 7},
+		{name: "skips a 204 no content", status: http.StatusNoContent, response: "", target: true},
+		{name: "skips an empty 200 body", status: http.StatusOK, response: "", target: true},
+		{name: "skips decoding into a nil target", status: http.StatusOK, response: "not json", target: false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c, mux, _, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc("/AZURE_DEVOPS_Project/_apis/build/builds/1", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				fmt.Fprint(w, tc.response)
+			})
+
+			request, err := c.NewRequest("DELETE", "_apis/build/builds/1", nil)
+			if err != nil {
+				t.Fatalf("returned error: %v", err)
+			}
+
+			var build azuredevops.Build
+			var target interface{}
+			if tc.target {
+				target = &build
+			}
+
+			response, err := c.Execute(request, target)
+			if err != nil {
+				t.Fatalf("returned error: %v", err)
+			}
+
+			if response == nil || response.StatusCode != tc.status {
+				t.Fatalf("expected the response with status %d to be returned", tc.status)
+			}
+
+			if build.ID != tc.id {
+				t.Fatalf("expected build id %d, got %d", tc.id, build.ID)
+			}
+		})
+	}
+}
+
+type countingTransport struct {
+	requests int
+}
+
+func (t *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.requests++
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestClient_WithHTTPClient(t *testing.T) {
+	_, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/AZURE_DEVOPS_Project/_apis/build/builds", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "{}")
+	})
+	mux.HandleFunc("/_apis/teams", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "{}")
+	})
+
+	transport := &countingTransport{}
+	hc := &http.Client{Transport: transport}
+	c := azuredevops.NewClient("AZURE_DEVOPS_Account", "AZURE_DEVOPS_Project", "AZURE_DEVOPS_TOKEN", azuredevops.WithHTTPClient(hc))
+	c.BaseURL = serverURL + baseURLPath
+
+	if c.HTTPClient() != hc {
+		t.Fatalf("expected the client to use the supplied http.Client")
+	}
+
+	if _, _, err := c.Builds.List(context.Background(), nil); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if _, _, err := c.Teams.List(context.Background(), nil); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if transport.requests != 2 {
+		t.Fatalf("expected 2 requests through the supplied transport, got %d", transport.requests)
+	}
+}
+
+func TestClient_HTTPClient_ZeroValue(t *testing.T) {
+	c := &azuredevops.Client{}
+	if c.HTTPClient() != http.DefaultClient {
+		t.Fatalf("expected a zero value Client to fall back to http.DefaultClient")
+	}
+}
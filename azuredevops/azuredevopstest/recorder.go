@@ -0,0 +1,260 @@
+package azuredevopstest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Mode controls whether a Recorder talks to the real API or a cassette
+type Mode int
+
+const (
+	// ModeReplay serves responses from the cassette and never touches the
+	// network. Requests missing from the cassette fail
+	ModeReplay Mode = iota
+	// ModeRecord sends requests to the real API and saves the traffic to
+	// the cassette when the Recorder is stopped
+	ModeRecord
+)
+
+// RecordEnv is the environment variable ModeFromEnv reads. Set it to any
+// non empty value to record new cassettes
+const RecordEnv = "AZURE_DEVOPS_RECORD"
+
+// ModeFromEnv returns ModeRecord if RecordEnv is set, otherwise ModeReplay
+func ModeFromEnv() Mode {
+	if os.Getenv(RecordEnv) != "" {
+		return ModeRecord
+	}
+	return ModeReplay
+}
+
+// scrubbedHeaders are never written to a cassette
+var scrubbedHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "X-Vss-Userdata"}
+
+// scrubbed replaces secrets in cassettes
+const scrubbed = "SCRUBBED"
+
+// Cassette is the on-disk form of recorded traffic
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a single request and the response it got
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the part of a request kept in a cassette
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is the part of a response kept in a cassette
+type RecordedResponse struct {
+	StatusCode int         `json:"status"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that records traffic to, or replays it
+// from, a cassette file
+type Recorder struct {
+	// Secrets are extra values, such as a personal access token, replaced
+	// wherever they appear in a recorded URL, header or body
+	Secrets []string
+	// Transport sends requests in ModeRecord, http.DefaultTransport if nil
+	Transport http.RoundTripper
+
+	path string
+	mode Mode
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// NewRecorder returns a Recorder for the cassette at path. In ModeReplay the
+// cassette must already exist
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{path: path, mode: mode}
+	if mode == ModeRecord {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading cassette %s: %w, record it by setting %s", path, err, RecordEnv)
+	}
+	if err := json.Unmarshal(data, &r.cassette); err != nil {
+		return nil, fmt.Errorf("decoding cassette %s: %w", path, err)
+	}
+	r.used = make([]bool, len(r.cassette.Interactions))
+	return r, nil
+}
+
+// HTTPClient returns an http.Client that sends requests through r, for use
+// with azuredevops.WithHTTPClient
+func (r *Recorder) HTTPClient() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Stop writes the cassette if recording. It does nothing when replaying
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+// RoundTrip records or replays request
+func (r *Recorder) RoundTrip(request *http.Request) (*http.Response, error) {
+	var body []byte
+	if request.Body != nil {
+		var err error
+		body, err = io.ReadAll(request.Body)
+		request.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		request.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	if r.mode == ModeRecord {
+		return r.record(request, body)
+	}
+	return r.replay(request, body)
+}
+
+func (r *Recorder) record(request *http.Request, body []byte) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	response, err := transport.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: RecordedRequest{
+			Method: request.Method,
+			URL:    r.scrub(request.URL.String()),
+			Header: r.scrubHeader(request.Header),
+			Body:   r.scrub(string(body)),
+		},
+		Response: RecordedResponse{
+			StatusCode: response.StatusCode,
+			Header:     r.scrubHeader(response.Header),
+			Body:       r.scrub(string(responseBody)),
+		},
+	})
+	r.mu.Unlock()
+
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+	return response, nil
+}
+
+func (r *Recorder) replay(request *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	want := requestKey(request.Method, r.scrub(request.URL.String()), r.scrub(string(body)))
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] {
+			continue
+		}
+		recorded := interaction.Request
+		if requestKey(recorded.Method, recorded.URL, recorded.Body) != want {
+			continue
+		}
+
+		r.used[i] = true
+		header := interaction.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       request,
+		}, nil
+	}
+
+	return nil, errors.New("azuredevopstest: no recorded interaction for " + request.Method + " " + request.URL.String())
+}
+
+// requestKey identifies a request by method, path, query and body. The host
+// is ignored so a cassette recorded against one organization replays
+// against a client pointed anywhere
+func requestKey(method, rawURL, body string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return method + " " + rawURL + " " + body
+	}
+	return method + " " + u.Path + "?" + u.Query().Encode() + " " + strings.TrimSpace(body)
+}
+
+// scrub replaces the recorder's secrets in s
+func (r *Recorder) scrub(s string) string {
+	for _, secret := range r.Secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, scrubbed)
+		}
+	}
+	return s
+}
+
+// scrubHeader returns a copy of h without credentials
+func (r *Recorder) scrubHeader(h http.Header) http.Header {
+	clean := http.Header{}
+	for key, values := range h {
+		for _, value := range values {
+			clean.Add(key, r.scrub(value))
+		}
+	}
+	for _, key := range scrubbedHeaders {
+		if clean.Get(key) != "" {
+			clean.Set(key, scrubbed)
+		}
+	}
+	return clean
+}
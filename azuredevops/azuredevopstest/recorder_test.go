@@ -0,0 +1,83 @@
+package azuredevopstest_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/benmatselby/go-azuredevops/azuredevops"
+	"github.com/benmatselby/go-azuredevops/azuredevops/azuredevopstest"
+)
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassettes", "builds.json")
+
+	server := azuredevopstest.NewServer("AZURE_DEVOPS_Project")
+	server.AddBuild(azuredevops.Build{Status: "completed", Result: "succeeded", BuildNumber: "20240101.1"})
+
+	recorder, err := azuredevopstest.NewRecorder(path, azuredevopstest.ModeRecord)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	recorder.Secrets = []string{azuredevopstest.Token}
+
+	c := server.Client(azuredevops.WithHTTPClient(recorder.HTTPClient()))
+	if _, _, err := c.Builds.List(context.Background(), nil); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if err := recorder.Stop(); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	server.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if strings.Contains(string(data), azuredevopstest.Token) || strings.Contains(string(data), "Basic ") {
+		t.Fatalf("expected credentials to be scrubbed from the cassette; got %s", data)
+	}
+
+	replayer, err := azuredevopstest.NewRecorder(path, azuredevopstest.ModeReplay)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	// The server has gone, so this can only succeed from the cassette
+	c = server.Client(azuredevops.WithHTTPClient(replayer.HTTPClient()))
+	builds, _, err := c.Builds.List(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(builds) != 1 || builds[0].BuildNumber != "20240101.1" {
+		t.Fatalf("expected the recorded build; got %v", builds)
+	}
+
+	if _, _, err := c.Builds.List(context.Background(), nil); err == nil {
+		t.Fatalf("expected an error once the recorded interaction was used up")
+	}
+}
+
+func TestRecorder_MissingCassette(t *testing.T) {
+	_, err := azuredevopstest.NewRecorder(filepath.Join(t.TempDir(), "missing.json"), azuredevopstest.ModeReplay)
+	if err == nil {
+		t.Fatalf("expected an error for a missing cassette, did not get one")
+	}
+}
+
+func TestModeFromEnv(t *testing.T) {
+	t.Setenv(azuredevopstest.RecordEnv, "")
+	if azuredevopstest.ModeFromEnv() != azuredevopstest.ModeReplay {
+		t.Fatalf("expected replay mode by default")
+	}
+
+	t.Setenv(azuredevopstest.RecordEnv, "1")
+	if azuredevopstest.ModeFromEnv() != azuredevopstest.ModeRecord {
+		t.Fatalf("expected record mode when %s is set", azuredevopstest.RecordEnv)
+	}
+}
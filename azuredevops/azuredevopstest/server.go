@@ -0,0 +1,825 @@
+/*
+Package azuredevopstest provides an in-memory fake of the Azure DevOps API
+for testing code that uses the azuredevops package.
+
+	server := azuredevopstest.NewServer("my-project")
+	defer server.Close()
+
+	server.AddBuild(azuredevops.Build{Status: azuredevops.BuildStatusCompleted, Result: azuredevops.BuildResultSucceeded})
+	client := server.Client()
+	builds, _, err := client.Builds.List(ctx, nil)
+
+The server keeps state, so a build queued through the client is returned by a
+later list, and records every request it receives for later assertions.
+*/
+package azuredevopstest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/benmatselby/go-azuredevops/azuredevops"
+)
+
+// Organization is the organization name the fake server pretends to be
+const Organization = "azuredevopstest"
+
+// Token is the personal access token the Client returned by Server.Client uses
+const Token = "azuredevopstest-token"
+
+// Request is a request received by the Server
+type Request struct {
+	Method string
+	URL    *url.URL
+	Header http.Header
+	Body   []byte
+}
+
+// Server is a fake Azure DevOps organization with a single project
+type Server struct {
+	// URL is the organization URL, suitable for Client.BaseURL
+	URL string
+	// Project is the name of the project the server hosts
+	Project string
+
+	server *httptest.Server
+
+	mu                 sync.Mutex
+	requests           []Request
+	builds             []azuredevops.Build
+	nextBuildID        int
+	workItems          map[int]azuredevops.WorkItem
+	iterations         map[string][]azuredevops.Iteration
+	iterationWorkItems map[string][]int
+	pullRequests       []azuredevops.PullRequest
+	teams              []azuredevops.Team
+	refs               map[string][]azuredevops.Ref
+	definitions        map[int][]azuredevops.BuildDefinition
+	deletedDefinitions map[int]bool
+	nextDefinitionID   int
+	folders            map[string]azuredevops.Folder
+}
+
+// NewServer starts a fake Azure DevOps server hosting project. Call Close
+// when finished with it
+func NewServer(project string) *Server {
+	s := &Server{
+		Project:            project,
+		nextBuildID:        1,
+		workItems:          map[int]azuredevops.WorkItem{},
+		iterations:         map[string][]azuredevops.Iteration{},
+		iterationWorkItems: map[string][]int{},
+		refs:               map[string][]azuredevops.Ref{},
+		definitions:        map[int][]azuredevops.BuildDefinition{},
+		deletedDefinitions: map[int]bool{},
+		nextDefinitionID:   1,
+		folders:            map[string]azuredevops.Folder{},
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL + "/" + Organization
+	return s
+}
+
+// Close shuts the server down
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns an azuredevops.Client pointed at the server
+func (s *Server) Client(opts ...azuredevops.ClientOption) *azuredevops.Client {
+	c, err := azuredevops.NewCollectionClient(s.URL, s.Project, Token, opts...)
+	if err != nil {
+		// The URL comes from httptest so can always be parsed
+		panic(err)
+	}
+	return c
+}
+
+// Requests returns every request the server has received, oldest first
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// AddBuild seeds a build, giving it the next ID if it does not have one.
+// The stored build is returned
+func (s *Server) AddBuild(build azuredevops.Build) azuredevops.Build {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addBuild(build)
+}
+
+func (s *Server) addBuild(build azuredevops.Build) azuredevops.Build {
+	if build.ID == 0 {
+		build.ID = s.nextBuildID
+	}
+	if build.ID >= s.nextBuildID {
+		s.nextBuildID = build.ID + 1
+	}
+	s.builds = append(s.builds, build)
+	return build
+}
+
+// Builds returns the builds the server holds
+func (s *Server) Builds() []azuredevops.Build {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]azuredevops.Build(nil), s.builds...)
+}
+
+// AddWorkItem seeds a work item
+func (s *Server) AddWorkItem(item azuredevops.WorkItem) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if item.Fields.ID == 0 {
+		item.Fields.ID = item.ID
+	}
+	s.workItems[item.ID] = item
+}
+
+// AddIteration seeds an iteration for team, holding the given work items
+func (s *Server) AddIteration(team string, iteration azuredevops.Iteration, workItemIDs ...int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.iterations[team] = append(s.iterations[team], iteration)
+	s.iterationWorkItems[iteration.ID] = append(s.iterationWorkItems[iteration.ID], workItemIDs...)
+}
+
+// AddPullRequest seeds a pull request
+func (s *Server) AddPullRequest(pr azuredevops.PullRequest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pullRequests = append(s.pullRequests, pr)
+}
+
+// AddTeam seeds a team
+func (s *Server) AddTeam(team azuredevops.Team) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.teams = append(s.teams, team)
+}
+
+// AddRef seeds a git reference, e.g. refs/heads/main, in repo
+func (s *Server) AddRef(repo string, ref azuredevops.Ref) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.refs[repo] = append(s.refs[repo], ref)
+}
+
+// AddDefinition seeds a build definition, giving it the next ID if it does
+// not have one and revision 1. The stored definition is returned
+func (s *Server) AddDefinition(definition azuredevops.BuildDefinition) azuredevops.BuildDefinition {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addDefinition(definition)
+}
+
+func (s *Server) addDefinition(definition azuredevops.BuildDefinition) azuredevops.BuildDefinition {
+	if definition.ID == 0 {
+		definition.ID = s.nextDefinitionID
+	}
+	if definition.ID >= s.nextDefinitionID {
+		s.nextDefinitionID = definition.ID + 1
+	}
+	if definition.Path == "" {
+		definition.Path = "\\"
+	}
+	definition.Revision = 1
+	s.definitions[definition.ID] = []azuredevops.BuildDefinition{definition}
+	return definition
+}
+
+// Definitions returns the current revision of every build definition the
+// server holds that hasn't been deleted, ordered by ID
+func (s *Server) Definitions() []azuredevops.BuildDefinition {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.currentDefinitions()
+}
+
+func (s *Server) currentDefinitions() []azuredevops.BuildDefinition {
+	var ids []int
+	for id := range s.definitions {
+		if !s.deletedDefinitions[id] {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+
+	definitions := []azuredevops.BuildDefinition{}
+	for _, id := range ids {
+		revisions := s.definitions[id]
+		definitions = append(definitions, revisions[len(revisions)-1])
+	}
+	return definitions
+}
+
+// AddFolder seeds a build definition folder. The stored folder is returned
+func (s *Server) AddFolder(folder azuredevops.Folder) azuredevops.Folder {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	folder.Path = folderPath(folder.Path)
+	s.folders[strings.ToLower(folder.Path)] = folder
+	return folder
+}
+
+// serveHTTP records the request and routes it to a handler
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		URL:    r.URL,
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+
+	if _, token, ok := r.BasicAuth(); !ok || token == "" {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+			writeError(w, http.StatusUnauthorized, "UnauthorizedRequestException", "No credentials were supplied")
+			return
+		}
+	}
+
+	// The client joins paths in a way that can leave empty segments, which
+	// the real API ignores
+	var segments []string
+	for _, segment := range strings.Split(strings.TrimPrefix(r.URL.Path, "/"+Organization), "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	// Requests either start with _apis, the project or the project and team
+	var team string
+	switch {
+	case len(segments) > 0 && segments[0] == "_apis":
+		segments = segments[1:]
+	case len(segments) > 1 && segments[0] == s.Project && segments[1] == "_apis":
+		segments = segments[2:]
+	case len(segments) > 2 && segments[0] == s.Project && segments[2] == "_apis":
+		team = segments[1]
+		segments = segments[3:]
+	default:
+		s.notFound(w, r)
+		return
+	}
+
+	route := strings.ToLower(strings.Join(segments, "/"))
+	switch {
+	case route == "build/builds" && r.Method == "GET":
+		s.listBuilds(w, r)
+	case route == "build/builds" && r.Method == "POST":
+		s.queueBuild(w, r)
+	case route == "build/builds" && r.Method == "PATCH":
+		s.updateBuilds(w, r)
+	case len(segments) == 3 && strings.HasPrefix(route, "build/builds/"):
+		s.build(w, r, segments[2])
+	case len(segments) == 4 && strings.HasPrefix(route, "build/builds/") && segments[3] == "tags" && r.Method == "POST":
+		s.addTags(w, r, segments[2])
+	case len(segments) == 5 && strings.HasPrefix(route, "build/builds/") && segments[3] == "tags" && r.Method == "DELETE":
+		s.removeTag(w, segments[2], segments[4])
+	case route == "build/tags" && r.Method == "GET":
+		s.listTags(w)
+	case route == "build/definitions" && r.Method == "GET":
+		s.listDefinitions(w, r)
+	case route == "build/definitions" && r.Method == "POST":
+		s.createDefinition(w, r)
+	case len(segments) == 3 && strings.HasPrefix(route, "build/definitions/"):
+		s.definition(w, r, segments[2])
+	case len(segments) == 4 && strings.HasPrefix(route, "build/definitions/") && segments[3] == "revisions":
+		s.listDefinitionRevisions(w, segments[2])
+	case len(segments) >= 2 && strings.HasPrefix(route+"/", "build/folders/"):
+		s.folder(w, r, folderPath(strings.Join(segments[2:], "\\")))
+	case route == "wit/workitems" && r.Method == "GET":
+		s.listWorkItems(w, r)
+	case route == "work/teamsettings/iterations" && r.Method == "GET":
+		s.listIterations(w, team)
+	case len(segments) == 5 && strings.HasPrefix(route, "work/teamsettings/iterations/") && segments[4] == "workitems":
+		s.listIterationWorkItems(w, segments[3])
+	case route == "git/pullrequests" && r.Method == "GET":
+		s.listPullRequests(w, r)
+	case route == "teams" && r.Method == "GET":
+		s.listTeams(w, r)
+	case len(segments) >= 5 && segments[0] == "git" && segments[1] == "repositories" && segments[3] == "refs":
+		s.listRefs(w, r, segments[2], strings.Join(segments[4:], "/"))
+	default:
+		s.notFound(w, r)
+	}
+}
+
+func (s *Server) notFound(w http.ResponseWriter, r *http.Request) {
+	writeError(w, http.StatusNotFound, "NotFoundException", fmt.Sprintf("azuredevopstest does not implement %s %s", r.Method, r.URL.Path))
+}
+
+// writeError writes an error in the shape Azure DevOps uses
+func writeError(w http.ResponseWriter, status int, typeKey, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"message":   message,
+		"typeKey":   typeKey,
+		"errorCode": 0,
+		"eventId":   3000,
+	})
+}
+
+// writeJSON writes v as the response body
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeList writes items in the {"count": n, "value": [...]} envelope
+func writeList(w http.ResponseWriter, items interface{}, count int) {
+	writeJSON(w, map[string]interface{}{"count": count, "value": items})
+}
+
+// splitInts parses a comma separated list of ids
+func splitInts(v string) map[int]bool {
+	ids := map[int]bool{}
+	for _, part := range strings.Split(v, ",") {
+		if id, err := strconv.Atoi(strings.TrimSpace(part)); err == nil {
+			ids[id] = true
+		}
+	}
+	return ids
+}
+
+// page applies $skip and $top to n items, returning the bounds to slice with
+func page(query url.Values, n int) (int, int) {
+	skip, _ := strconv.Atoi(query.Get("$skip"))
+	if skip > n {
+		skip = n
+	}
+	end := n
+	if top, err := strconv.Atoi(query.Get("$top")); err == nil && top > 0 && skip+top < n {
+		end = skip + top
+	}
+	return skip, end
+}
+
+func (s *Server) listBuilds(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	ids := splitInts(query.Get("buildIds"))
+	definitions := splitInts(query.Get("definitions"))
+
+	builds := []azuredevops.Build{}
+	for _, build := range s.builds {
+		if len(ids) > 0 && !ids[build.ID] {
+			continue
+		}
+		if len(definitions) > 0 && !definitions[build.Definition.ID] {
+			continue
+		}
+		if !matchesFilter(query.Get("statusFilter"), build.Status.String()) {
+			continue
+		}
+		if !matchesFilter(query.Get("resultFilter"), build.Result.String()) {
+			continue
+		}
+		if branch := query.Get("branchName"); branch != "" && build.Branch != branch {
+			continue
+		}
+		if tags := query.Get("tagFilters"); tags != "" && !hasTags(build.Tags, strings.Split(tags, ",")) {
+			continue
+		}
+		builds = append(builds, build)
+	}
+
+	start, end := page(query, len(builds))
+	writeList(w, builds[start:end], end-start)
+}
+
+// matchesFilter reports whether value is in the comma separated filter. An
+// empty filter, or one containing "all", matches everything
+func matchesFilter(filter, value string) bool {
+	if filter == "" {
+		return true
+	}
+	for _, f := range strings.Split(filter, ",") {
+		if f == "all" || strings.EqualFold(f, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) queueBuild(w http.ResponseWriter, r *http.Request) {
+	var build azuredevops.Build
+	if err := json.NewDecoder(r.Body).Decode(&build); err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequestException", err.Error())
+		return
+	}
+
+	build.ID = 0
+	build.Status = azuredevops.BuildStatusNotStarted
+	build.Result = ""
+	writeJSON(w, s.addBuild(build))
+}
+
+// findBuild returns the index of the build with id, or -1
+func (s *Server) findBuild(id int) int {
+	for i, build := range s.builds {
+		if build.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// build gets, updates, retries or deletes a single build
+func (s *Server) build(w http.ResponseWriter, r *http.Request, id string) {
+	buildID, _ := strconv.Atoi(id)
+	i := s.findBuild(buildID)
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "BuildNotFoundException", fmt.Sprintf("The requested build %s could not be found.", id))
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		writeJSON(w, s.builds[i])
+	case "PATCH":
+		if r.URL.Query().Get("retry") == "true" {
+			s.builds[i].Status = azuredevops.BuildStatusInProgress
+			s.builds[i].Result = azuredevops.BuildResultNone
+			writeJSON(w, s.builds[i])
+			return
+		}
+
+		var update azuredevops.BuildUpdate
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidRequestException", err.Error())
+			return
+		}
+		s.applyBuildUpdate(i, update)
+		writeJSON(w, s.builds[i])
+	case "DELETE":
+		if s.builds[i].KeepForever {
+			writeError(w, http.StatusBadRequest, "InvalidRequestException", "The build is retained and cannot be deleted.")
+			return
+		}
+		s.builds = append(s.builds[:i], s.builds[i+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.notFound(w, r)
+	}
+}
+
+// hasTags reports whether tags includes every one of want
+func hasTags(tags, want []string) bool {
+	for _, w := range want {
+		found := false
+		for _, tag := range tags {
+			if strings.EqualFold(tag, w) {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) addTags(w http.ResponseWriter, r *http.Request, id string) {
+	buildID, _ := strconv.Atoi(id)
+	i := s.findBuild(buildID)
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "BuildNotFoundException", fmt.Sprintf("The requested build %s could not be found.", id))
+		return
+	}
+
+	var tags []string
+	if err := json.NewDecoder(r.Body).Decode(&tags); err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequestException", err.Error())
+		return
+	}
+
+	for _, tag := range tags {
+		if !hasTags(s.builds[i].Tags, []string{tag}) {
+			s.builds[i].Tags = append(s.builds[i].Tags, tag)
+		}
+	}
+	writeList(w, s.builds[i].Tags, len(s.builds[i].Tags))
+}
+
+func (s *Server) removeTag(w http.ResponseWriter, id, tag string) {
+	buildID, _ := strconv.Atoi(id)
+	i := s.findBuild(buildID)
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "BuildNotFoundException", fmt.Sprintf("The requested build %s could not be found.", id))
+		return
+	}
+
+	tags := []string{}
+	for _, t := range s.builds[i].Tags {
+		if !strings.EqualFold(t, tag) {
+			tags = append(tags, t)
+		}
+	}
+	s.builds[i].Tags = tags
+	writeList(w, tags, len(tags))
+}
+
+func (s *Server) listTags(w http.ResponseWriter) {
+	tags := []string{}
+	for _, build := range s.builds {
+		for _, tag := range build.Tags {
+			if !hasTags(tags, []string{tag}) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	sort.Strings(tags)
+	writeList(w, tags, len(tags))
+}
+
+func (s *Server) updateBuilds(w http.ResponseWriter, r *http.Request) {
+	var updates []azuredevops.BuildUpdate
+	if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequestException", err.Error())
+		return
+	}
+
+	builds := []azuredevops.Build{}
+	for _, update := range updates {
+		i := s.findBuild(update.ID)
+		if i < 0 {
+			writeError(w, http.StatusNotFound, "BuildNotFoundException", fmt.Sprintf("The requested build %d could not be found.", update.ID))
+			return
+		}
+		s.applyBuildUpdate(i, update)
+		builds = append(builds, s.builds[i])
+	}
+	writeList(w, builds, len(builds))
+}
+
+func (s *Server) applyBuildUpdate(i int, update azuredevops.BuildUpdate) {
+	if update.Status != "" {
+		s.builds[i].Status = update.Status
+	}
+	if update.KeepForever != nil {
+		s.builds[i].KeepForever = *update.KeepForever
+	}
+	if update.RetainedByRelease != nil {
+		s.builds[i].RetainedByRelease = azuredevops.Bool(*update.RetainedByRelease)
+	}
+}
+
+func (s *Server) listDefinitions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	definitions := []azuredevops.BuildDefinition{}
+	for _, definition := range s.currentDefinitions() {
+		if name := query.Get("name"); name != "" && !strings.EqualFold(definition.Name, name) {
+			continue
+		}
+		if path := query.Get("path"); path != "" && !strings.EqualFold(definition.Path, path) {
+			continue
+		}
+		definitions = append(definitions, definition)
+	}
+
+	start, end := page(query, len(definitions))
+	writeList(w, definitions[start:end], end-start)
+}
+
+func (s *Server) createDefinition(w http.ResponseWriter, r *http.Request) {
+	var definition azuredevops.BuildDefinition
+	if err := json.NewDecoder(r.Body).Decode(&definition); err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequestException", err.Error())
+		return
+	}
+
+	definition.ID = 0
+	writeJSON(w, s.addDefinition(definition))
+}
+
+// definition gets, updates, deletes or restores a single build definition
+func (s *Server) definition(w http.ResponseWriter, r *http.Request, id string) {
+	definitionID, _ := strconv.Atoi(id)
+	revisions, ok := s.definitions[definitionID]
+	if !ok || (s.deletedDefinitions[definitionID] && r.Method != "PATCH") {
+		writeError(w, http.StatusNotFound, "DefinitionNotFoundException", fmt.Sprintf("The requested build definition %s could not be found.", id))
+		return
+	}
+	current := revisions[len(revisions)-1]
+
+	switch r.Method {
+	case "GET":
+		revision, err := strconv.Atoi(r.URL.Query().Get("revision"))
+		if err != nil {
+			writeJSON(w, current)
+			return
+		}
+		if revision < 1 || revision > len(revisions) {
+			writeError(w, http.StatusNotFound, "DefinitionNotFoundException", fmt.Sprintf("Revision %d of build definition %s could not be found.", revision, id))
+			return
+		}
+		writeJSON(w, revisions[revision-1])
+	case "PUT":
+		var definition azuredevops.BuildDefinition
+		if err := json.NewDecoder(r.Body).Decode(&definition); err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidRequestException", err.Error())
+			return
+		}
+		if definition.Revision != current.Revision {
+			writeError(w, http.StatusConflict, "DefinitionRevisionConflictException", fmt.Sprintf("The build definition %s has been updated since revision %d.", id, definition.Revision))
+			return
+		}
+		definition.ID = definitionID
+		definition.Revision = current.Revision + 1
+		s.definitions[definitionID] = append(revisions, definition)
+		writeJSON(w, definition)
+	case "DELETE":
+		s.deletedDefinitions[definitionID] = true
+		w.WriteHeader(http.StatusNoContent)
+	case "PATCH":
+		if r.URL.Query().Get("deleted") != "false" {
+			s.notFound(w, r)
+			return
+		}
+		delete(s.deletedDefinitions, definitionID)
+		writeJSON(w, current)
+	default:
+		s.notFound(w, r)
+	}
+}
+
+func (s *Server) listDefinitionRevisions(w http.ResponseWriter, id string) {
+	definitionID, _ := strconv.Atoi(id)
+	revisions, ok := s.definitions[definitionID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "DefinitionNotFoundException", fmt.Sprintf("The requested build definition %s could not be found.", id))
+		return
+	}
+
+	history := []azuredevops.BuildDefinitionRevision{}
+	for i, revision := range revisions {
+		changeType := "update"
+		if i == 0 {
+			changeType = "add"
+		}
+		history = append(history, azuredevops.BuildDefinitionRevision{
+			Revision:   revision.Revision,
+			Name:       revision.Name,
+			ChangeType: changeType,
+			Comment:    revision.Comment,
+		})
+	}
+	writeList(w, history, len(history))
+}
+
+// folder lists, creates or deletes the build definition folders at path
+func (s *Server) folder(w http.ResponseWriter, r *http.Request, path string) {
+	key := strings.ToLower(path)
+
+	switch r.Method {
+	case "GET":
+		folders := []azuredevops.Folder{}
+		for k, folder := range s.folders {
+			if k == key || key == "\\" || strings.HasPrefix(k, key+"\\") {
+				folders = append(folders, folder)
+			}
+		}
+		sort.Slice(folders, func(i, j int) bool {
+			return strings.ToLower(folders[i].Path) < strings.ToLower(folders[j].Path)
+		})
+		writeList(w, folders, len(folders))
+	case "PUT":
+		var folder azuredevops.Folder
+		if err := json.NewDecoder(r.Body).Decode(&folder); err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidRequestException", err.Error())
+			return
+		}
+		if _, ok := s.folders[key]; ok || key == "\\" {
+			writeError(w, http.StatusConflict, "FolderExistsException", fmt.Sprintf("A folder already exists at %s.", path))
+			return
+		}
+		folder.Path = path
+		s.folders[key] = folder
+		writeJSON(w, folder)
+	case "DELETE":
+		if _, ok := s.folders[key]; !ok {
+			writeError(w, http.StatusNotFound, "FolderNotFoundException", fmt.Sprintf("The folder %s could not be found.", path))
+			return
+		}
+		// Deleting a folder deletes everything below it
+		for k := range s.folders {
+			if k == key || strings.HasPrefix(k, key+"\\") {
+				delete(s.folders, k)
+			}
+		}
+		for _, definition := range s.currentDefinitions() {
+			if k := strings.ToLower(folderPath(definition.Path)); k == key || strings.HasPrefix(k, key+"\\") {
+				s.deletedDefinitions[definition.ID] = true
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.notFound(w, r)
+	}
+}
+
+// folderPath cleans a folder path the way the API does, so \a\b, a/b and
+// \a\b\ are all \a\b
+func folderPath(path string) string {
+	var segments []string
+	for _, segment := range strings.FieldsFunc(path, func(r rune) bool { return r == '\\' || r == '/' }) {
+		if segment = strings.TrimSpace(segment); segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return "\\" + strings.Join(segments, "\\")
+}
+
+func (s *Server) listWorkItems(w http.ResponseWriter, r *http.Request) {
+	ids := splitInts(r.URL.Query().Get("ids"))
+
+	var sorted []int
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Ints(sorted)
+
+	items := []azuredevops.WorkItem{}
+	for _, id := range sorted {
+		if item, ok := s.workItems[id]; ok {
+			items = append(items, item)
+		}
+	}
+	writeList(w, items, len(items))
+}
+
+func (s *Server) listIterations(w http.ResponseWriter, team string) {
+	iterations := append([]azuredevops.Iteration{}, s.iterations[team]...)
+	writeList(w, iterations, len(iterations))
+}
+
+func (s *Server) listIterationWorkItems(w http.ResponseWriter, iterationID string) {
+	relations := []azuredevops.WorkItemRelationship{}
+	for _, id := range s.iterationWorkItems[iterationID] {
+		relations = append(relations, azuredevops.WorkItemRelationship{Target: azuredevops.WorkItemRelation{ID: id}})
+	}
+	writeJSON(w, azuredevops.WorkItemsResponse{WorkItemRelationships: relations})
+}
+
+func (s *Server) listPullRequests(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	// The API only returns active pull requests unless asked otherwise
+	status := query.Get("searchCriteria.status")
+	if status == "" {
+		status = "active"
+	}
+
+	prs := []azuredevops.PullRequest{}
+	for _, pr := range s.pullRequests {
+		if !matchesFilter(status, pr.Status.String()) {
+			continue
+		}
+		prs = append(prs, pr)
+	}
+
+	start, end := page(query, len(prs))
+	writeList(w, prs[start:end], end-start)
+}
+
+func (s *Server) listTeams(w http.ResponseWriter, r *http.Request) {
+	start, end := page(r.URL.Query(), len(s.teams))
+	teams := append([]azuredevops.Team{}, s.teams[start:end]...)
+	writeList(w, teams, len(teams))
+}
+
+func (s *Server) listRefs(w http.ResponseWriter, r *http.Request, repo, refType string) {
+	prefix := "refs/" + refType + "/"
+	filter := r.URL.Query().Get("filter")
+
+	refs := []azuredevops.Ref{}
+	for _, ref := range s.refs[repo] {
+		if !strings.HasPrefix(ref.Name, prefix) {
+			continue
+		}
+		if filter != "" && !strings.HasPrefix(strings.TrimPrefix(ref.Name, prefix), filter) {
+			continue
+		}
+		refs = append(refs, ref)
+	}
+	writeList(w, refs, len(refs))
+}
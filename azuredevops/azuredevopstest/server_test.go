@@ -0,0 +1,341 @@
+package azuredevopstest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/benmatselby/go-azuredevops/azuredevops"
+	"github.com/benmatselby/go-azuredevops/azuredevops/azuredevopstest"
+)
+
+func TestServer_Builds(t *testing.T) {
+	server := azuredevopstest.NewServer("AZURE_DEVOPS_Project")
+	defer server.Close()
+
+	server.AddBuild(azuredevops.Build{Status: "completed", Result: "succeeded", Definition: azuredevops.BuildDefinition{ID: 1}})
+	server.AddBuild(azuredevops.Build{Status: "completed", Result: "failed", Definition: azuredevops.BuildDefinition{ID: 2}})
+
+	c := server.Client()
+	ctx := context.Background()
+
+	build := &azuredevops.Build{Definition: azuredevops.BuildDefinition{ID: 1}}
+	if err := c.Builds.Queue(ctx, build, nil); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if build.ID != 3 || build.Status != "notStarted" {
+		t.Fatalf("expected queued build 3 to be notStarted; got %d %s", build.ID, build.Status)
+	}
+
+	builds, _, err := c.Builds.List(ctx, &azuredevops.BuildsListOptions{Definitions: "1"})
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(builds) != 2 {
+		t.Fatalf("expected 2 builds for definition 1; got %d", len(builds))
+	}
+
+	builds, _, err = c.Builds.List(ctx, &azuredevops.BuildsListOptions{Result: []azuredevops.BuildResult{azuredevops.BuildResultFailed}})
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(builds) != 1 || builds[0].ID != 2 {
+		t.Fatalf("expected the failed build; got %v", builds)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 3 || requests[0].Method != "POST" {
+		t.Fatalf("expected 3 requests starting with the POST; got %d", len(requests))
+	}
+}
+
+func TestServer_WorkItemsForIteration(t *testing.T) {
+	server := azuredevopstest.NewServer("AZURE_DEVOPS_Project")
+	defer server.Close()
+
+	iteration := azuredevops.Iteration{ID: "sprint-1", Name: "Sprint 1"}
+	server.AddIteration("AZURE_DEVOPS_TEAM", iteration, 1, 2)
+	server.AddWorkItem(azuredevops.WorkItem{ID: 1, Fields: azuredevops.WorkItemFields{Title: "one", Tags: "a; b"}})
+	server.AddWorkItem(azuredevops.WorkItem{ID: 2, Fields: azuredevops.WorkItemFields{Title: "two"}})
+	server.AddWorkItem(azuredevops.WorkItem{ID: 3, Fields: azuredevops.WorkItemFields{Title: "three"}})
+
+	c := server.Client()
+	ctx := context.Background()
+
+	found, err := c.Iterations.GetByName(ctx, "AZURE_DEVOPS_TEAM", "Sprint 1")
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if found == nil || found.ID != "sprint-1" {
+		t.Fatalf("expected to find sprint-1; got %v", found)
+	}
+
+	items, err := c.WorkItems.GetForIteration(ctx, "AZURE_DEVOPS_TEAM", *found)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(items) != 2 || items[0].Fields.Title != "one" || len(items[0].Fields.TagList) != 2 {
+		t.Fatalf("expected work items one and two; got %v", items)
+	}
+}
+
+func TestServer_PullRequestsTeamsAndRefs(t *testing.T) {
+	server := azuredevopstest.NewServer("AZURE_DEVOPS_Project")
+	defer server.Close()
+
+	server.AddPullRequest(azuredevops.PullRequest{ID: 1, Status: "active"})
+	server.AddPullRequest(azuredevops.PullRequest{ID: 2, Status: "completed"})
+	for _, name := range []string{"one", "two", "three"} {
+		server.AddTeam(azuredevops.Team{Name: name})
+	}
+	server.AddRef("repo", azuredevops.Ref{Name: "refs/heads/main"})
+	server.AddRef("repo", azuredevops.Ref{Name: "refs/heads/feature/x"})
+	server.AddRef("repo", azuredevops.Ref{Name: "refs/tags/v1"})
+
+	c := server.Client()
+	ctx := context.Background()
+
+	prs, count, err := c.PullRequests.List(ctx, nil)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if count != 1 || prs[0].ID != 1 {
+		t.Fatalf("expected only the active pull request; got %v", prs)
+	}
+
+	teams, err := c.Teams.ListAll(ctx, &azuredevops.TeamsListOptions{Top: 2})
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(teams) != 3 {
+		t.Fatalf("expected 3 teams; got %d", len(teams))
+	}
+
+	refs, count, _, err := c.Git.ListRefs(ctx, "repo", "heads", &azuredevops.GitRefListOptions{Filter: "feature"})
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if count != 1 || refs[0].Name != "refs/heads/feature/x" {
+		t.Fatalf("expected the feature branch; got %v", refs)
+	}
+}
+
+func TestServer_Unauthenticated(t *testing.T) {
+	server := azuredevopstest.NewServer("AZURE_DEVOPS_Project")
+	defer server.Close()
+
+	c := server.Client()
+	c.AuthToken = ""
+
+	_, _, err := c.Builds.List(context.Background(), nil)
+	if !azuredevops.IsUnauthorized(err) {
+		t.Fatalf("expected an unauthorized error; got %v", err)
+	}
+}
+
+func TestServer_NotImplemented(t *testing.T) {
+	server := azuredevopstest.NewServer("AZURE_DEVOPS_Project")
+	defer server.Close()
+
+	_, err := server.Client().Boards.List(context.Background(), "AZURE_DEVOPS_TEAM")
+	if !azuredevops.IsNotFound(err) {
+		t.Fatalf("expected a not found error; got %v", err)
+	}
+}
+
+func TestServer_BuildLifecycle(t *testing.T) {
+	server := azuredevopstest.NewServer("AZURE_DEVOPS_Project")
+	defer server.Close()
+
+	server.AddBuild(azuredevops.Build{Status: azuredevops.BuildStatusInProgress})
+	server.AddBuild(azuredevops.Build{Status: azuredevops.BuildStatusCompleted, Result: azuredevops.BuildResultFailed})
+
+	c := server.Client()
+	ctx := context.Background()
+
+	build, err := c.Builds.Cancel(ctx, 1)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if build.Status != azuredevops.BuildStatusCancelling {
+		t.Fatalf("expected build 1 to be cancelling; got %s", build.Status)
+	}
+
+	build, err = c.Builds.Retry(ctx, 2)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if build.Status != azuredevops.BuildStatusInProgress {
+		t.Fatalf("expected build 2 to be in progress again; got %s", build.Status)
+	}
+
+	if _, err := c.Builds.Update(ctx, 2, &azuredevops.BuildUpdate{KeepForever: azuredevops.Bool(true)}); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if err := c.Builds.Delete(ctx, 2); err == nil {
+		t.Fatalf("expected an error deleting a retained build, did not get one")
+	}
+
+	if err := c.Builds.Delete(ctx, 1); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	_, err = c.Builds.Get(ctx, 1)
+	if !azuredevops.IsNotFound(err) {
+		t.Fatalf("expected build 1 to be gone; got %v", err)
+	}
+}
+
+func TestServer_BuildTags(t *testing.T) {
+	server := azuredevopstest.NewServer("AZURE_DEVOPS_Project")
+	defer server.Close()
+
+	server.AddBuild(azuredevops.Build{Tags: []string{"nightly"}})
+	server.AddBuild(azuredevops.Build{})
+
+	c := server.Client()
+	ctx := context.Background()
+
+	tags, err := c.Builds.AddTags(ctx, 2, "approved-for-prod", "nightly")
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(tags) != 2 {
+		t.Fatalf("expected 2 tags on build 2; got %v", tags)
+	}
+
+	builds, _, err := c.Builds.List(ctx, &azuredevops.BuildsListOptions{Tags: "approved-for-prod"})
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(builds) != 1 || builds[0].ID != 2 {
+		t.Fatalf("expected to find build 2 by its tag; got %v", builds)
+	}
+
+	if tags, err = c.Builds.RemoveTag(ctx, 2, "nightly"); err != nil || len(tags) != 1 {
+		t.Fatalf("expected 1 tag left; got %v, %v", tags, err)
+	}
+
+	tags, err = c.Builds.ListTags(ctx)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(tags) != 2 || tags[0] != "approved-for-prod" || tags[1] != "nightly" {
+		t.Fatalf("expected the project's tags; got %v", tags)
+	}
+}
+
+func TestServer_DefinitionRevisions(t *testing.T) {
+	server := azuredevopstest.NewServer("AZURE_DEVOPS_Project")
+	defer server.Close()
+
+	added := server.AddDefinition(azuredevops.BuildDefinition{Name: "ci"})
+
+	c := server.Client()
+	ctx := context.Background()
+
+	definition, err := c.BuildDefinitions.Get(ctx, added.ID)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	definition.Description = "continuous integration"
+	updated, err := c.BuildDefinitions.Update(ctx, definition)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if updated.Revision != 2 {
+		t.Fatalf("expected revision 2; got %d", updated.Revision)
+	}
+
+	if _, err = c.BuildDefinitions.Update(ctx, definition); !errors.Is(err, azuredevops.ErrRevisionConflict) {
+		t.Fatalf("expected a revision conflict for a stale update; got %v", err)
+	}
+
+	revisions, err := c.BuildDefinitions.ListRevisions(ctx, added.ID)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(revisions) != 2 {
+		t.Fatalf("expected 2 revisions; got %d", len(revisions))
+	}
+
+	if err := c.BuildDefinitions.Delete(ctx, added.ID); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(server.Definitions()) != 0 {
+		t.Fatalf("expected the definition to be deleted; got %v", server.Definitions())
+	}
+
+	if _, err := c.BuildDefinitions.Restore(ctx, added.ID); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(server.Definitions()) != 1 {
+		t.Fatalf("expected the definition to be restored; got %v", server.Definitions())
+	}
+}
+
+func TestServer_Folders(t *testing.T) {
+	server := azuredevopstest.NewServer("AZURE_DEVOPS_Project")
+	defer server.Close()
+
+	server.AddFolder(azuredevops.Folder{Path: `\services`})
+	server.AddDefinition(azuredevops.BuildDefinition{Name: "api", Path: `\services\api`})
+	server.AddDefinition(azuredevops.BuildDefinition{Name: "docs"})
+
+	c := server.Client()
+	ctx := context.Background()
+
+	if _, err := c.BuildDefinitions.CreateFolder(ctx, `\services\api`, ""); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if _, err := c.BuildDefinitions.CreateFolder(ctx, `\services`, ""); !azuredevops.IsConflict(err) {
+		t.Fatalf("expected a conflict creating an existing folder; got %v", err)
+	}
+
+	tree, err := c.BuildDefinitions.FolderTree(ctx)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	api := tree.Find(`\services\api`)
+	if api == nil || api.Folder == nil || len(api.Definitions) != 1 {
+		t.Fatalf("expected the api folder with its definition; got %+v", api)
+	}
+
+	if err := c.BuildDefinitions.DeleteFolder(ctx, `\services`); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	folders, err := c.BuildDefinitions.ListFolders(ctx, "")
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(folders) != 0 {
+		t.Fatalf("expected the folders to be deleted; got %v", folders)
+	}
+
+	if definitions := server.Definitions(); len(definitions) != 1 || definitions[0].Name != "docs" {
+		t.Fatalf("expected only the definition outside the folder to be left; got %v", definitions)
+	}
+}
@@ -1,6 +1,7 @@
 package azuredevops
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 )
@@ -31,13 +32,14 @@ type BoardColumn struct {
 
 // List returns list of the boards
 // utilising https://docs.microsoft.com/en-gb/rest/api/vsts/work/boards/list
-func (s *BoardsService) List(team string) ([]Board, error) {
+func (s *BoardsService) List(ctx context.Context, team string) ([]Board, error) {
 	URL := fmt.Sprintf(
-		"/%s/_apis/work/boards?api-version=4.1-preview",
+		"/%s/_apis/work/boards?api-version=%s",
 		url.PathEscape(team),
+		s.client.APIVersion(BoardsAPI),
 	)
 
-	request, err := s.client.NewRequest("GET", URL, nil)
+	request, err := s.client.NewRequestWithContext(ctx, "GET", URL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -48,14 +50,15 @@ func (s *BoardsService) List(team string) ([]Board, error) {
 }
 
 // Get returns a single board utilising https://docs.microsoft.com/en-gb/rest/api/vsts/work/boards/get
-func (s *BoardsService) Get(team string, id string) (*Board, error) {
+func (s *BoardsService) Get(ctx context.Context, team string, id string) (*Board, error) {
 	URL := fmt.Sprintf(
-		"/%s/_apis/work/boards/%s?api-version=4.1-preview",
+		"/%s/_apis/work/boards/%s?api-version=%s",
 		url.PathEscape(team),
 		id,
+		s.client.APIVersion(BoardsAPI),
 	)
 
-	request, err := s.client.NewRequest("GET", URL, nil)
+	request, err := s.client.NewRequestWithContext(ctx, "GET", URL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,178 @@
+package azuredevops
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// BuildArtifactsResponse is the wrapper around the main response for the
+// List of build artifacts
+type BuildArtifactsResponse struct {
+	Count     int             `json:"count"`
+	Artifacts []BuildArtifact `json:"value"`
+}
+
+// BuildArtifact describes something a build published
+type BuildArtifact struct {
+	ID       int                   `json:"id"`
+	Name     string                `json:"name"`
+	Source   string                `json:"source"`
+	Resource BuildArtifactResource `json:"resource"`
+}
+
+// BuildArtifactResource describes where an artifact is stored. Type is one
+// of the ArtifactType constants
+type BuildArtifactResource struct {
+	Type        string            `json:"type"`
+	Data        string            `json:"data"`
+	Properties  map[string]string `json:"properties"`
+	URL         string            `json:"url"`
+	DownloadURL string            `json:"downloadUrl"`
+}
+
+// Size returns the size of the artifact in bytes, if Azure DevOps reported it
+func (r BuildArtifactResource) Size() int64 {
+	size, _ := strconv.ParseInt(r.Properties["artifactsize"], 10, 64)
+	return size
+}
+
+// ListArtifacts returns the artifacts of a build
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/artifacts/list
+func (s *BuildsService) ListArtifacts(ctx context.Context, buildID int) ([]BuildArtifact, error) {
+	URL := fmt.Sprintf("_apis/build/builds/%d/artifacts?api-version=%s", buildID, s.client.APIVersion(BuildArtifactsAPI))
+
+	request, err := s.client.NewRequestWithContext(ctx, "GET", URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	var response BuildArtifactsResponse
+	_, err = s.client.Execute(request, &response)
+
+	return response.Artifacts, err
+}
+
+// GetArtifact returns a single artifact of a build by name
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/artifacts/get%20artifact
+func (s *BuildsService) GetArtifact(ctx context.Context, buildID int, name string) (*BuildArtifact, error) {
+	URL := fmt.Sprintf(
+		"_apis/build/builds/%d/artifacts?artifactName=%s&api-version=%s",
+		buildID,
+		url.QueryEscape(name),
+		s.client.APIVersion(BuildArtifactsAPI),
+	)
+
+	request, err := s.client.NewRequestWithContext(ctx, "GET", URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	var response BuildArtifact
+	_, err = s.client.Execute(request, &response)
+
+	return &response, err
+}
+
+// Artifact resource types
+const (
+	// ArtifactTypeContainer is an artifact published to the build's file container
+	ArtifactTypeContainer = "Container"
+	// ArtifactTypePipeline is an artifact published as a pipeline artifact
+	ArtifactTypePipeline = "PipelineArtifact"
+	// ArtifactTypeFilePath is an artifact copied to a file share
+	ArtifactTypeFilePath = "FilePath"
+)
+
+// ErrArtifactNotDownloadable is returned by DownloadArtifact for artifacts
+// the API doesn't serve, such as those published to a file share
+var ErrArtifactNotDownloadable = errors.New("azuredevops: artifact cannot be downloaded")
+
+// DownloadArtifact streams an artifact of a build as a zip archive. Files in
+// the archive are under a directory named after the artifact. Container and
+// pipeline artifacts are downloaded from the URL the API gives for them,
+// with credentials only if that URL is on one of the Client's hosts, while
+// file share artifacts return an error wrapping ErrArtifactNotDownloadable,
+// with Resource.Data holding the share path. The caller must close the
+// returned reader
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/artifacts/get%20artifact
+func (s *BuildsService) DownloadArtifact(ctx context.Context, buildID int, name string) (io.ReadCloser, error) {
+	artifact, err := s.GetArtifact(ctx, buildID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	resource := artifact.Resource
+	switch {
+	case resource.Type == ArtifactTypeFilePath:
+		return nil, fmt.Errorf("%w: %s is on a file share at %s", ErrArtifactNotDownloadable, name, resource.Data)
+	case resource.Type != ArtifactTypeContainer && resource.Type != ArtifactTypePipeline:
+		return nil, fmt.Errorf("%w: %s has unsupported type %q", ErrArtifactNotDownloadable, name, resource.Type)
+	case resource.DownloadURL == "":
+		return nil, fmt.Errorf("%w: %s has no download URL", ErrArtifactNotDownloadable, name)
+	}
+
+	request, err := s.client.newRequest(ctx, "GET", resource.DownloadURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "application/zip")
+
+	response, err := s.client.executeStream(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Body, nil
+}
+
+// DownloadArtifactFile writes a single file from an artifact of a build to
+// w. path is relative to the artifact, e.g. bin/app.zip for the file
+// drop/bin/app.zip in the drop artifact. The archive is spooled to a
+// temporary file, as zip files can't be read as a stream
+func (s *BuildsService) DownloadArtifactFile(ctx context.Context, buildID int, name, path string, w io.Writer) error {
+	body, err := s.DownloadArtifact(ctx, buildID, name)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	spool, err := os.CreateTemp("", "azuredevops-artifact-*.zip")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+
+	size, err := io.Copy(spool, body)
+	if err != nil {
+		return err
+	}
+
+	archive, err := zip.NewReader(spool, size)
+	if err != nil {
+		return fmt.Errorf("reading artifact %s: %w", name, err)
+	}
+
+	path = strings.TrimPrefix(path, "/")
+	for _, file := range archive.File {
+		if file.Name != path && file.Name != name+"/"+path {
+			continue
+		}
+
+		contents, err := file.Open()
+		if err != nil {
+			return err
+		}
+		defer contents.Close()
+
+		_, err = io.Copy(w, contents)
+		return err
+	}
+
+	return fmt.Errorf("azuredevops: %s not found in artifact %s", path, name)
+}
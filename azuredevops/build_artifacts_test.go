@@ -0,0 +1,274 @@
+package azuredevops_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/benmatselby/go-azuredevops/azuredevops"
+)
+
+const (
+	buildArtifactsURL = "/AZURE_DEVOPS_Project/_apis/build/builds/42/artifacts"
+	// https://docs.microsoft.com/en-us/rest/api/azure/devops/build/artifacts/list
+	buildArtifactsResponse = `{
+		"count": 2,
+		"value": [
+			{
+				"id": 1,
+				"name": "drop",
+				"source": "12f1170f-54f2-53f3-20dd-22fc7dff55f9",
+				"resource": {
+					"type": "Container",
+					"data": "#/1/drop",
+					"properties": {"localpath": "/home/vsts/work/1/a", "artifactsize": "2048"},
+					"url": "https://dev.azure.com/fabrikam/_apis/build/builds/42/artifacts?artifactName=drop&api-version=4.1",
+					"downloadUrl": "https://dev.azure.com/fabrikam/_apis/build/builds/42/artifacts?artifactName=drop&api-version=4.1&%24format=zip"
+				}
+			},
+			{
+				"id": 2,
+				"name": "logs",
+				"source": "12f1170f-54f2-53f3-20dd-22fc7dff55f9",
+				"resource": {
+					"type": "FilePath",
+					"data": "\\\\share\\logs"
+				}
+			}
+		]
+	}`
+)
+
+func TestBuildsService_ListArtifacts(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(buildArtifactsURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, buildArtifactsResponse)
+	})
+
+	artifacts, err := c.Builds.ListArtifacts(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(artifacts) != 2 {
+		t.Fatalf("expected 2 artifacts; got %d", len(artifacts))
+	}
+
+	if artifacts[0].Name != "drop" || artifacts[0].Resource.Type != "Container" || artifacts[0].Resource.Size() != 2048 {
+		t.Fatalf("expected the 2048 byte drop container; got %v", artifacts[0])
+	}
+
+	if artifacts[1].Resource.Size() != 0 {
+		t.Fatalf("expected no size for a file share artifact; got %d", artifacts[1].Resource.Size())
+	}
+}
+
+func TestBuildsService_GetArtifact(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(buildArtifactsURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testURL(t, r, buildArtifactsURL+"?artifactName=my+drop&api-version=4.1")
+		fmt.Fprint(w, `{"id": 3, "name": "my drop", "resource": {"type": "PipelineArtifact"}}`)
+	})
+
+	artifact, err := c.Builds.GetArtifact(context.Background(), 42, "my drop")
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if artifact.ID != 3 || artifact.Resource.Type != "PipelineArtifact" {
+		t.Fatalf("expected pipeline artifact 3; got %v", artifact)
+	}
+}
+
+// artifactZip returns a zip archive holding files
+func artifactZip(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, contents := range files {
+		f, err := archive.Create(name)
+		if err != nil {
+			t.Fatalf("returned error: %v", err)
+		}
+		io.WriteString(f, contents)
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// serveArtifact serves the drop artifact of build 42 as resource, with the
+// archive at its download URL
+func serveArtifact(t *testing.T, mux *http.ServeMux, resource string, archive []byte) {
+	mux.HandleFunc(buildArtifactsURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.URL.Query().Get("$format") == "zip" {
+			w.Header().Set("Content-Type", "application/zip")
+			w.Write(archive)
+			return
+		}
+		testURL(t, r, buildArtifactsURL+"?artifactName=drop&api-version=4.1")
+		fmt.Fprintf(w, `{"id": 1, "name": "drop", "resource": %s}`, resource)
+	})
+	mux.HandleFunc("/_apis/artifact/pipelineartifact/content", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/zip")
+		w.Write(archive)
+	})
+}
+
+func TestBuildsService_DownloadArtifact(t *testing.T) {
+	archive := artifactZip(t, map[string]string{"drop/app.txt": "hello"})
+
+	tt := []struct {
+		name     string
+		resource string
+		err      bool
+	}{
+		{name: "container", resource: `{"type": "Container", "data": "#/1/drop", "downloadUrl": "{server}/testing/AZURE_DEVOPS_Project/_apis/build/builds/42/artifacts?artifactName=drop&api-version=4.1&%24format=zip"}`},
+		{name: "pipeline artifact", resource: `{"type": "PipelineArtifact", "data": "F1B2C3", "downloadUrl": "{server}/testing/_apis/artifact/pipelineartifact/content?format=zip"}`},
+		{name: "file share", resource: `{"type": "FilePath", "data": "\\\\share\\drop"}`, err: true},
+		{name: "no download URL", resource: `{"type": "Container", "data": "#/1/drop"}`, err: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c, mux, serverURL, teardown := setup()
+			defer teardown()
+
+			serveArtifact(t, mux, strings.ReplaceAll(tc.resource, "{server}", serverURL), archive)
+
+			body, err := c.Builds.DownloadArtifact(context.Background(), 42, "drop")
+			if tc.err {
+				if !errors.Is(err, azuredevops.ErrArtifactNotDownloadable) {
+					t.Fatalf("expected the artifact not to be downloadable; got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("returned error: %v", err)
+			}
+			defer body.Close()
+
+			data, err := io.ReadAll(body)
+			if err != nil {
+				t.Fatalf("returned error: %v", err)
+			}
+
+			if !bytes.Equal(data, archive) {
+				t.Fatalf("expected the zip archive to be streamed unchanged")
+			}
+		})
+	}
+}
+
+func TestBuildsService_DownloadArtifactForeignHost(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	archive := artifactZip(t, map[string]string{"drop/app.txt": "hello"})
+
+	var foreignAuth []string
+	foreign := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		foreignAuth = r.Header.Values("Authorization")
+		w.Header().Set("Content-Type", "application/zip")
+		w.Write(archive)
+	}))
+	defer foreign.Close()
+
+	mux.HandleFunc(buildArtifactsURL, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			t.Errorf("expected the artifact to be looked up with credentials")
+		}
+		fmt.Fprintf(w, `{"id": 1, "name": "drop", "resource": {"type": "PipelineArtifact", "downloadUrl": "%s/drop.zip"}}`, foreign.URL)
+	})
+
+	body, err := c.Builds.DownloadArtifact(context.Background(), 42, "drop")
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if !bytes.Equal(data, archive) {
+		t.Fatalf("expected the zip archive from the other host")
+	}
+
+	if len(foreignAuth) != 0 {
+		t.Fatalf("expected no credentials to be sent to another host; got %v", foreignAuth)
+	}
+}
+
+func TestBuildsService_DownloadArtifactFileShareError(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	serveArtifact(t, mux, `{"type": "FilePath", "data": "\\\\share\\drop"}`, nil)
+
+	_, err := c.Builds.DownloadArtifact(context.Background(), 42, "drop")
+	if err == nil || !strings.Contains(err.Error(), `\\share\drop`) {
+		t.Fatalf("expected the error to name the file share; got %v", err)
+	}
+}
+
+func TestBuildsService_DownloadArtifactFile(t *testing.T) {
+	archive := artifactZip(t, map[string]string{
+		"drop/":            "",
+		"drop/bin/app.txt": "hello",
+		"drop/readme.md":   "read me",
+	})
+
+	tt := []struct {
+		name     string
+		path     string
+		expected string
+		err      bool
+	}{
+		{name: "path within the artifact", path: "bin/app.txt", expected: "hello"},
+		{name: "leading slash", path: "/readme.md", expected: "read me"},
+		{name: "path including the artifact", path: "drop/bin/app.txt", expected: "hello"},
+		{name: "missing file", path: "bin/missing.txt", err: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c, mux, serverURL, teardown := setup()
+			defer teardown()
+
+			serveArtifact(t, mux, fmt.Sprintf(`{"type": "Container", "downloadUrl": "%s/testing/AZURE_DEVOPS_Project/_apis/build/builds/42/artifacts?artifactName=drop&%%24format=zip"}`, serverURL), archive)
+
+			var out strings.Builder
+			err := c.Builds.DownloadArtifactFile(context.Background(), 42, "drop", tc.path, &out)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected an error, did not get one")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("returned error: %v", err)
+			}
+
+			if out.String() != tc.expected {
+				t.Fatalf("expected %q; got %q", tc.expected, out.String())
+			}
+		})
+	}
+}
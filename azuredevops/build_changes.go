@@ -0,0 +1,228 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// BuildChangesResponse is the wrapper around the main response for the List
+// of build changes
+type BuildChangesResponse struct {
+	Count   int           `json:"count"`
+	Changes []BuildChange `json:"value"`
+}
+
+// BuildChange describes a commit, or changeset, that went into a build
+type BuildChange struct {
+	ID               string       `json:"id"`
+	Message          string       `json:"message"`
+	MessageTruncated bool         `json:"messageTruncated"`
+	Type             string       `json:"type"`
+	Author           *IdentityRef `json:"author,omitempty"`
+	Pusher           string       `json:"pusher"`
+	Timestamp        Time         `json:"timestamp"`
+	Location         string       `json:"location"`
+	DisplayURI       string       `json:"displayUri"`
+}
+
+// WorkItemRefsResponse is the wrapper around the main response for the List
+// of work items linked to a build
+type WorkItemRefsResponse struct {
+	Count        int           `json:"count"`
+	WorkItemRefs []WorkItemRef `json:"value"`
+}
+
+// WorkItemRef is a reference to a work item. The API sends the ID as a string
+type WorkItemRef struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+// BuildChangesOptions describes what the request to the API should look like
+type BuildChangesOptions struct {
+	Top   int    `url:"$top,omitempty"`
+	Token string `url:"continuationToken,omitempty"`
+}
+
+// GetChanges returns a page of the changes that went into a build along with
+// the continuation token for the next page, which is empty when there are no
+// more changes. GetAllChanges follows the tokens
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/builds/get%20build%20changes
+func (s *BuildsService) GetChanges(ctx context.Context, buildID int, opts *BuildChangesOptions) ([]BuildChange, string, error) {
+	URL := fmt.Sprintf("_apis/build/builds/%d/changes?api-version=%s", buildID, s.client.APIVersion(BuildChangesAPI))
+	URL, err := addOptions(URL, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return s.getChanges(ctx, URL)
+}
+
+// GetAllChanges returns every change that went into a build, following
+// continuation tokens
+func (s *BuildsService) GetAllChanges(ctx context.Context, buildID int, opts *BuildChangesOptions) ([]BuildChange, error) {
+	return ListAll(ctx, buildChangesPage(opts, func(ctx context.Context, opts *BuildChangesOptions) ([]BuildChange, string, error) {
+		return s.GetChanges(ctx, buildID, opts)
+	}))
+}
+
+// GetChangesBetween returns a page of the changes made after fromBuildID up
+// to and including toBuildID, both builds of the same definition, along with
+// the continuation token for the next page
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/builds/get%20changes%20between%20builds
+func (s *BuildsService) GetChangesBetween(ctx context.Context, fromBuildID, toBuildID int, opts *BuildChangesOptions) ([]BuildChange, string, error) {
+	URL := fmt.Sprintf(
+		"_apis/build/changes?fromBuildId=%d&toBuildId=%d&api-version=%s",
+		fromBuildID,
+		toBuildID,
+		s.client.APIVersion(BuildChangesBetweenAPI),
+	)
+	URL, err := addOptions(URL, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return s.getChanges(ctx, URL)
+}
+
+// GetAllChangesBetween returns every change made after fromBuildID up to and
+// including toBuildID, following continuation tokens
+func (s *BuildsService) GetAllChangesBetween(ctx context.Context, fromBuildID, toBuildID int, opts *BuildChangesOptions) ([]BuildChange, error) {
+	return ListAll(ctx, buildChangesPage(opts, func(ctx context.Context, opts *BuildChangesOptions) ([]BuildChange, string, error) {
+		return s.GetChangesBetween(ctx, fromBuildID, toBuildID, opts)
+	}))
+}
+
+func (s *BuildsService) getChanges(ctx context.Context, URL string) ([]BuildChange, string, error) {
+	request, err := s.client.NewRequestWithContext(ctx, "GET", URL, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	var response BuildChangesResponse
+	resp, err := s.client.Execute(request, &response)
+
+	return response.Changes, continuationToken(resp), err
+}
+
+// GetWorkItemRefs returns a page of references to the work items linked to a
+// build along with the continuation token for the next page.
+// GetAllWorkItemRefs follows the tokens
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/builds/get%20build%20work%20items%20refs
+func (s *BuildsService) GetWorkItemRefs(ctx context.Context, buildID int, opts *BuildChangesOptions) ([]WorkItemRef, string, error) {
+	URL := fmt.Sprintf("_apis/build/builds/%d/workitems?api-version=%s", buildID, s.client.APIVersion(BuildChangesAPI))
+	URL, err := addOptions(URL, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return s.getWorkItemRefs(ctx, URL)
+}
+
+// GetAllWorkItemRefs returns references to every work item linked to a
+// build, following continuation tokens
+func (s *BuildsService) GetAllWorkItemRefs(ctx context.Context, buildID int, opts *BuildChangesOptions) ([]WorkItemRef, error) {
+	return ListAll(ctx, buildChangesPage(opts, func(ctx context.Context, opts *BuildChangesOptions) ([]WorkItemRef, string, error) {
+		return s.GetWorkItemRefs(ctx, buildID, opts)
+	}))
+}
+
+// GetWorkItemRefsBetween returns a page of references to the work items
+// linked to the changes after fromBuildID up to and including toBuildID,
+// along with the continuation token for the next page
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/builds/get%20work%20items%20between%20builds
+func (s *BuildsService) GetWorkItemRefsBetween(ctx context.Context, fromBuildID, toBuildID int, opts *BuildChangesOptions) ([]WorkItemRef, string, error) {
+	URL := fmt.Sprintf(
+		"_apis/build/workitems?fromBuildId=%d&toBuildId=%d&api-version=%s",
+		fromBuildID,
+		toBuildID,
+		s.client.APIVersion(BuildChangesBetweenAPI),
+	)
+	URL, err := addOptions(URL, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return s.getWorkItemRefs(ctx, URL)
+}
+
+// GetAllWorkItemRefsBetween returns references to every work item linked to
+// the changes after fromBuildID up to and including toBuildID, following
+// continuation tokens
+func (s *BuildsService) GetAllWorkItemRefsBetween(ctx context.Context, fromBuildID, toBuildID int, opts *BuildChangesOptions) ([]WorkItemRef, error) {
+	return ListAll(ctx, buildChangesPage(opts, func(ctx context.Context, opts *BuildChangesOptions) ([]WorkItemRef, string, error) {
+		return s.GetWorkItemRefsBetween(ctx, fromBuildID, toBuildID, opts)
+	}))
+}
+
+func (s *BuildsService) getWorkItemRefs(ctx context.Context, URL string) ([]WorkItemRef, string, error) {
+	request, err := s.client.NewRequestWithContext(ctx, "GET", URL, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	var response WorkItemRefsResponse
+	resp, err := s.client.Execute(request, &response)
+
+	return response.WorkItemRefs, continuationToken(resp), err
+}
+
+// buildChangesPage returns a PageFunc that calls list with opts and the
+// token of each page
+func buildChangesPage[T any](opts *BuildChangesOptions, list func(context.Context, *BuildChangesOptions) ([]T, string, error)) PageFunc[T] {
+	return func(ctx context.Context, token string) ([]T, string, error) {
+		pageOpts := BuildChangesOptions{}
+		if opts != nil {
+			pageOpts = *opts
+		}
+		if token != "" {
+			pageOpts.Token = token
+		}
+		return list(ctx, &pageOpts)
+	}
+}
+
+// GetWorkItems returns every work item linked to a build, with the same
+// fields as WorkItemsService.GetForIteration
+func (s *BuildsService) GetWorkItems(ctx context.Context, buildID int) ([]WorkItem, error) {
+	refs, err := s.GetAllWorkItemRefs(ctx, buildID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.resolveWorkItems(ctx, refs)
+}
+
+// GetWorkItemsBetween returns every work item linked to the changes after
+// fromBuildID up to and including toBuildID, with the same fields as
+// WorkItemsService.GetForIteration
+func (s *BuildsService) GetWorkItemsBetween(ctx context.Context, fromBuildID, toBuildID int) ([]WorkItem, error) {
+	refs, err := s.GetAllWorkItemRefsBetween(ctx, fromBuildID, toBuildID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.resolveWorkItems(ctx, refs)
+}
+
+func (s *BuildsService) resolveWorkItems(ctx context.Context, refs []WorkItemRef) ([]WorkItem, error) {
+	ids, err := WorkItemIDs(refs)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.WorkItems.GetByIDs(ctx, ids)
+}
+
+// WorkItemIDs returns the IDs of refs as integers, dropping duplicates
+func WorkItemIDs(refs []WorkItemRef) ([]int, error) {
+	seen := map[int]bool{}
+	var ids []int
+	for _, ref := range refs {
+		id, err := strconv.Atoi(ref.ID)
+		if err != nil {
+			return nil, fmt.Errorf("azuredevops: invalid work item id %q", ref.ID)
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
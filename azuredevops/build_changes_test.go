@@ -0,0 +1,215 @@
+package azuredevops_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/benmatselby/go-azuredevops/azuredevops"
+)
+
+const (
+	buildChangesURL = "/AZURE_DEVOPS_Project/_apis/build/builds/42/changes"
+	// https://docs.microsoft.com/en-us/rest/api/azure/devops/build/builds/get%20build%20changes
+	buildChangesResponse = `{
+		"count": 2,
+		"value": [
+			{
+				"id": "b8fe3e9b1d6c4e5e8a4e1f2e1d8a3c0e2f6b4a1d",
+				"message": "Fix the flaky login test",
+				"type": "TfsGit",
+				"author": {"displayName": "Jamal Hartnett", "uniqueName": "fabrikamfiber4@hotmail.com"},
+				"timestamp": "2019-01-15T23:12:08Z",
+				"location": "https://dev.azure.com/fabrikam/_apis/git/repositories/278d5cd2/commits/b8fe3e9b",
+				"displayUri": "https://dev.azure.com/fabrikam/Fabrikam/_git/app/commit/b8fe3e9b"
+			},
+			{
+				"id": "9991b4f66def4c0a9ad8f9f27043ece7eddcf1c7",
+				"message": "Update the readme",
+				"messageTruncated": true,
+				"type": "TfsGit",
+				"timestamp": "2019-01-15T20:01:00Z"
+			}
+		]
+	}`
+	buildWorkItemsURL = "/AZURE_DEVOPS_Project/_apis/build/builds/42/workitems"
+	// https://docs.microsoft.com/en-us/rest/api/azure/devops/build/builds/get%20build%20work%20items%20refs
+	buildWorkItemsResponse = `{
+		"count": 3,
+		"value": [
+			{"id": "1", "url": "https://dev.azure.com/fabrikam/_apis/wit/workItems/1"},
+			{"id": "3", "url": "https://dev.azure.com/fabrikam/_apis/wit/workItems/3"},
+			{"id": "1", "url": "https://dev.azure.com/fabrikam/_apis/wit/workItems/1"}
+		]
+	}`
+)
+
+func TestBuildsService_GetChanges(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(buildChangesURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testURL(t, r, buildChangesURL+"?%24top=50&api-version=4.1")
+		w.Header().Set("x-ms-continuationtoken", "next-page")
+		fmt.Fprint(w, buildChangesResponse)
+	})
+
+	changes, token, err := c.Builds.GetChanges(context.Background(), 42, &azuredevops.BuildChangesOptions{Top: 50})
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if token != "next-page" {
+		t.Fatalf("expected the continuation token; got %q", token)
+	}
+
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes; got %d", len(changes))
+	}
+
+	if changes[0].Message != "Fix the flaky login test" || changes[0].Author.DisplayName != "Jamal Hartnett" || changes[0].Timestamp.IsZero() {
+		t.Fatalf("expected the first commit with its author; got %v", changes[0])
+	}
+
+	if !changes[1].MessageTruncated || changes[1].Author != nil {
+		t.Fatalf("expected a truncated commit without an author; got %v", changes[1])
+	}
+}
+
+func TestBuildsService_GetAllChanges(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	var tokens []string
+	mux.HandleFunc(buildChangesURL, func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get("continuationToken")
+		tokens = append(tokens, token)
+		if token == "" {
+			w.Header().Set("x-ms-continuationtoken", "page-2")
+		}
+		fmt.Fprint(w, buildChangesResponse)
+	})
+
+	changes, err := c.Builds.GetAllChanges(context.Background(), 42, nil)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(changes) != 4 {
+		t.Fatalf("expected the changes from both pages; got %d", len(changes))
+	}
+
+	if fmt.Sprint(tokens) != "[ page-2]" {
+		t.Fatalf("expected the second page to be requested with its token; got %v", tokens)
+	}
+}
+
+func TestBuildsService_GetChangesBetween(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/AZURE_DEVOPS_Project/_apis/build/changes", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testURL(t, r, "/AZURE_DEVOPS_Project/_apis/build/changes?fromBuildId=40&toBuildId=42&api-version=4.1-preview.2")
+		fmt.Fprint(w, buildChangesResponse)
+	})
+
+	changes, _, err := c.Builds.GetChangesBetween(context.Background(), 40, 42, nil)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes; got %d", len(changes))
+	}
+}
+
+func TestBuildsService_GetWorkItemRefs(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(buildWorkItemsURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, buildWorkItemsResponse)
+	})
+
+	refs, _, err := c.Builds.GetWorkItemRefs(context.Background(), 42, nil)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	ids, err := azuredevops.WorkItemIDs(refs)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(ids, []int{1, 3}) {
+		t.Fatalf("expected work items 1 and 3; got %v", ids)
+	}
+}
+
+func TestBuildsService_GetWorkItems(t *testing.T) {
+	tt := []struct {
+		name     string
+		URL      string
+		get      func(c *azuredevops.Client) ([]azuredevops.WorkItem, error)
+		expected string
+	}{
+		{
+			name: "for a build",
+			URL:  buildWorkItemsURL,
+			get: func(c *azuredevops.Client) ([]azuredevops.WorkItem, error) {
+				return c.Builds.GetWorkItems(context.Background(), 42)
+			},
+		},
+		{
+			name: "between builds",
+			URL:  "/AZURE_DEVOPS_Project/_apis/build/workitems",
+			get: func(c *azuredevops.Client) ([]azuredevops.WorkItem, error) {
+				return c.Builds.GetWorkItemsBetween(context.Background(), 40, 42)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c, mux, _, teardown := setup()
+			defer teardown()
+
+			// The refs come in two pages
+			mux.HandleFunc(tc.URL, func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "GET")
+				if r.URL.Query().Get("continuationToken") == "page-2" {
+					fmt.Fprint(w, `{"count": 1, "value": [{"id": "5"}]}`)
+					return
+				}
+				w.Header().Set("x-ms-continuationtoken", "page-2")
+				fmt.Fprint(w, buildWorkItemsResponse)
+			})
+			mux.HandleFunc(getURL, func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "GET")
+				testURL(t, r, "/AZURE_DEVOPS_Project/_apis/wit/workitems?ids=1,3,5&fields=System.Id,System.Title,System.State,System.WorkItemType,Microsoft.VSTS.Scheduling.StoryPoints,System.BoardColumn,System.CreatedBy,System.AssignedTo,System.Tags&errorPolicy=omit&api-version=6.1-preview.3")
+				fmt.Fprint(w, getResponse)
+			})
+
+			workItems, err := tc.get(c)
+			if err != nil {
+				t.Fatalf("returned error: %v", err)
+			}
+
+			if len(workItems) != 3 {
+				t.Fatalf("expected the 3 work items in the response; got %d", len(workItems))
+			}
+		})
+	}
+}
+
+func TestWorkItemIDs_Invalid(t *testing.T) {
+	_, err := azuredevops.WorkItemIDs([]azuredevops.WorkItemRef{{ID: "one"}})
+	if err == nil {
+		t.Fatalf("expected an error for a non numeric id, did not get one")
+	}
+}
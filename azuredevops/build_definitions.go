@@ -1,7 +1,12 @@
 package azuredevops
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 )
 
 // BuildDefinitionsService handles communication with the build definitions methods on the API
@@ -22,38 +27,393 @@ type Repository struct {
 	Type               string                 `json:"type,omitempty"`
 	Name               string                 `json:"name,omitempty"`
 	URL                string                 `json:"url,omitempty"`
-	RootFolder         string                 `json:"root_folder"`
-	Properties         map[string]interface{} `json:"properties"`
-	Clean              string                 `json:"clean"`
-	DefaultBranch      string                 `json:"default_branch"`
-	CheckoutSubmodules bool                   `json:"checkout_submodules"`
+	RootFolder         string                 `json:"rootFolder,omitempty"`
+	Properties         map[string]interface{} `json:"properties,omitempty"`
+	Clean              string                 `json:"clean,omitempty"`
+	DefaultBranch      string                 `json:"defaultBranch,omitempty"`
+	CheckoutSubmodules bool                   `json:"checkoutSubmodules,omitempty"`
 }
 
-// BuildDefinition represents a build definition
+// BuildDefinition represents a build definition. Definitions returned by
+// List only have the summary fields set unless IncludeAllProperties is used.
+// Only the common fields are modelled, but a definition read from the API
+// keeps the rest, so it can be changed and saved without losing settings
 type BuildDefinition struct {
-	ID         int         `json:"id"`
-	Name       string      `json:"name"`
-	Repository *Repository `json:"repository,omitempty"`
+	ID                        int                                `json:"id,omitempty"`
+	Name                      string                             `json:"name,omitempty"`
+	Path                      string                             `json:"path,omitempty"`
+	Revision                  int                                `json:"revision,omitempty"`
+	Type                      string                             `json:"type,omitempty"`
+	Quality                   string                             `json:"quality,omitempty"`
+	QueueStatus               string                             `json:"queueStatus,omitempty"`
+	Description               string                             `json:"description,omitempty"`
+	BuildNumberFormat         string                             `json:"buildNumberFormat,omitempty"`
+	Comment                   string                             `json:"comment,omitempty"`
+	CreatedDate               *Time                              `json:"createdDate,omitempty"`
+	AuthoredBy                *IdentityRef                       `json:"authoredBy,omitempty"`
+	Project                   *TeamProjectReference              `json:"project,omitempty"`
+	Repository                *Repository                        `json:"repository,omitempty"`
+	Process                   *BuildProcess                      `json:"process,omitempty"`
+	Queue                     *AgentPoolQueue                    `json:"queue,omitempty"`
+	Triggers                  []BuildTrigger                     `json:"triggers,omitempty"`
+	Variables                 map[string]BuildDefinitionVariable `json:"variables,omitempty"`
+	VariableGroups            []VariableGroupReference           `json:"variableGroups,omitempty"`
+	RetentionRules            []RetentionPolicy                  `json:"retentionRules,omitempty"`
+	Options                   []BuildOption                      `json:"options,omitempty"`
+	Tags                      []string                           `json:"tags,omitempty"`
+	Properties                map[string]interface{}             `json:"properties,omitempty"`
+	JobAuthorizationScope     string                             `json:"jobAuthorizationScope,omitempty"`
+	JobTimeoutInMinutes       int                                `json:"jobTimeoutInMinutes,omitempty"`
+	JobCancelTimeoutInMinutes int                                `json:"jobCancelTimeoutInMinutes,omitempty"`
+	BadgeEnabled              bool                               `json:"badgeEnabled,omitempty"`
+	URI                       string                             `json:"uri,omitempty"`
+	URL                       string                             `json:"url,omitempty"`
+
+	// raw is the JSON the definition was decoded from, so the fields that
+	// aren't modelled, such as demands and processParameters, are sent back
+	// untouched by Update
+	raw json.RawMessage
+}
+
+// UnmarshalJSON decodes a definition, keeping the JSON it was decoded from
+func (d *BuildDefinition) UnmarshalJSON(data []byte) error {
+	type plain BuildDefinition
+	if err := json.Unmarshal(data, (*plain)(d)); err != nil {
+		return err
+	}
+	if string(data) != "null" {
+		d.raw = append(json.RawMessage(nil), data...)
+	}
+	return nil
+}
+
+// MarshalJSON encodes a definition. A definition decoded from the API is
+// encoded as the JSON it was decoded from with the fields that have changed
+// since applied, so nothing the API sent is lost
+func (d BuildDefinition) MarshalJSON() ([]byte, error) {
+	type plain BuildDefinition
+	current, err := json.Marshal(plain(d))
+	if err != nil || d.raw == nil {
+		return current, err
+	}
+
+	var decoded plain
+	if err := json.Unmarshal(d.raw, &decoded); err != nil {
+		return nil, err
+	}
+	base, err := json.Marshal(decoded)
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeJSON(d.raw, base, current)
+}
+
+// TeamProjectReference is a reference to a project
+type TeamProjectReference struct {
+	ID    string `json:"id,omitempty"`
+	Name  string `json:"name,omitempty"`
+	State string `json:"state,omitempty"`
+	URL   string `json:"url,omitempty"`
+}
+
+// AgentPoolQueue is the agent queue a definition builds on
+type AgentPoolQueue struct {
+	ID   int    `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url,omitempty"`
+	Pool *struct {
+		ID       int    `json:"id,omitempty"`
+		Name     string `json:"name,omitempty"`
+		IsHosted bool   `json:"isHosted,omitempty"`
+	} `json:"pool,omitempty"`
+}
+
+// Build process types
+const (
+	// DesignerProcess is a definition built from classic editor phases
+	DesignerProcess = 1
+	// YAMLProcess is a definition built from a YAML file in the repository
+	YAMLProcess = 2
+)
+
+// BuildProcess describes how a definition builds. YAML definitions set
+// YAMLFilename, designer definitions keep their phases in Phases, which is
+// passed through untouched so updates don't lose them
+type BuildProcess struct {
+	Type         int             `json:"type"`
+	YAMLFilename string          `json:"yamlFilename,omitempty"`
+	Phases       json.RawMessage `json:"phases,omitempty"`
+}
+
+// BuildTrigger is a trigger of a definition. TriggerType is one of
+// continuousIntegration, pullRequest, schedule, buildCompletion or
+// gatedCheckIn, and decides which of the other fields apply
+type BuildTrigger struct {
+	TriggerType                  string          `json:"triggerType"`
+	BranchFilters                []string        `json:"branchFilters,omitempty"`
+	PathFilters                  []string        `json:"pathFilters,omitempty"`
+	BatchChanges                 bool            `json:"batchChanges,omitempty"`
+	MaxConcurrentBuildsPerBranch int             `json:"maxConcurrentBuildsPerBranch,omitempty"`
+	SettingsSourceType           int             `json:"settingsSourceType,omitempty"`
+	Schedules                    json.RawMessage `json:"schedules,omitempty"`
+	Forks                        json.RawMessage `json:"forks,omitempty"`
+}
+
+// BuildDefinitionVariable is a variable of a definition. The value of a
+// secret variable is never returned by the API, and a secret with an empty
+// Value is sent back as null so saving the definition keeps its value
+type BuildDefinitionVariable struct {
+	Value         string `json:"value"`
+	IsSecret      bool   `json:"isSecret,omitempty"`
+	AllowOverride bool   `json:"allowOverride,omitempty"`
+}
+
+// MarshalJSON encodes v, leaving out the value of a secret that isn't set
+func (v BuildDefinitionVariable) MarshalJSON() ([]byte, error) {
+	variable := struct {
+		Value         *string `json:"value"`
+		IsSecret      bool    `json:"isSecret,omitempty"`
+		AllowOverride bool    `json:"allowOverride,omitempty"`
+	}{&v.Value, v.IsSecret, v.AllowOverride}
+
+	if v.IsSecret && v.Value == "" {
+		variable.Value = nil
+	}
+	return json.Marshal(variable)
+}
+
+// VariableGroupReference is a variable group linked to a definition
+type VariableGroupReference struct {
+	ID   int    `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// RetentionPolicy is a retention rule of a definition
+type RetentionPolicy struct {
+	Branches              []string `json:"branches,omitempty"`
+	Artifacts             []string `json:"artifacts,omitempty"`
+	ArtifactTypesToDelete []string `json:"artifactTypesToDelete,omitempty"`
+	DaysToKeep            int      `json:"daysToKeep"`
+	MinimumToKeep         int      `json:"minimumToKeep"`
+	DeleteBuildRecord     bool     `json:"deleteBuildRecord"`
+	DeleteTestResults     bool     `json:"deleteTestResults"`
 }
 
+// BuildOption is an option, such as a work item on failure, of a definition
+type BuildOption struct {
+	Enabled    bool `json:"enabled"`
+	Definition struct {
+		ID string `json:"id"`
+	} `json:"definition"`
+	Inputs map[string]string `json:"inputs,omitempty"`
+}
+
+// BuildDefinitionRevision describes a change to a definition
+type BuildDefinitionRevision struct {
+	Revision      int          `json:"revision"`
+	Name          string       `json:"name"`
+	ChangeType    string       `json:"changeType"`
+	ChangedBy     *IdentityRef `json:"changedBy,omitempty"`
+	ChangedDate   Time         `json:"changedDate"`
+	Comment       string       `json:"comment"`
+	DefinitionURL string       `json:"definitionUrl"`
+}
+
+// BuildDefinitionRevisionsResponse is the wrapper around the main response
+// for the List of definition revisions
+type BuildDefinitionRevisionsResponse struct {
+	Count     int                       `json:"count"`
+	Revisions []BuildDefinitionRevision `json:"value"`
+}
+
+// ErrRevisionConflict is returned by BuildDefinitionsService.Update when the
+// definition has been changed since it was read. Get it again, reapply the
+// change and retry
+var ErrRevisionConflict = errors.New("azuredevops: build definition has been changed since it was read")
+
 // BuildDefinitionsListOptions describes what the request to the API should look like
 type BuildDefinitionsListOptions struct {
 	Path                 string `url:"path,omitempty"`
 	IncludeAllProperties bool   `url:"includeAllProperties,omitempty"`
+	Top                  int    `url:"$top,omitempty"`
+	Token                string `url:"continuationToken,omitempty"`
 }
 
-// List returns a list of build definitions
+// List returns a list of build definitions along with the continuation token
+// for the next page, which is empty when there are no more definitions
 // utilising https://docs.microsoft.com/en-gb/rest/api/vsts/build/definitions/list
-func (s *BuildDefinitionsService) List(opts *BuildDefinitionsListOptions) ([]BuildDefinition, error) {
-	URL := fmt.Sprintf("_apis/build/definitions?api-version=5.0-preview.6")
+func (s *BuildDefinitionsService) List(ctx context.Context, opts *BuildDefinitionsListOptions) ([]BuildDefinition, string, error) {
+	URL := fmt.Sprintf("_apis/build/definitions?api-version=%s", s.client.APIVersion(BuildDefinitionsAPI))
 	URL, err := addOptions(URL, opts)
 
-	request, err := s.client.NewRequest("GET", URL, nil)
+	request, err := s.client.NewRequestWithContext(ctx, "GET", URL, nil)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	var response BuildDefinitionsListResponse
+	resp, err := s.client.Execute(request, &response)
+
+	return response.BuildDefinitions, continuationToken(resp), err
+}
+
+// Pager returns a Pager that walks every page of build definitions matching opts
+func (s *BuildDefinitionsService) Pager(opts *BuildDefinitionsListOptions) *Pager[BuildDefinition] {
+	return NewPager(s.listPage(opts))
+}
+
+// ListAll returns every build definition matching opts, following
+// continuation tokens
+func (s *BuildDefinitionsService) ListAll(ctx context.Context, opts *BuildDefinitionsListOptions) ([]BuildDefinition, error) {
+	return ListAll(ctx, s.listPage(opts))
+}
+
+func (s *BuildDefinitionsService) listPage(opts *BuildDefinitionsListOptions) PageFunc[BuildDefinition] {
+	return func(ctx context.Context, token string) ([]BuildDefinition, string, error) {
+		pageOpts := BuildDefinitionsListOptions{}
+		if opts != nil {
+			pageOpts = *opts
+		}
+		if token != "" {
+			pageOpts.Token = token
+		}
+		return s.List(ctx, &pageOpts)
+	}
+}
+
+// Get returns a build definition
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/definitions/get
+func (s *BuildDefinitionsService) Get(ctx context.Context, definitionID int) (*BuildDefinition, error) {
+	return s.get(ctx, fmt.Sprintf("_apis/build/definitions/%d?api-version=%s", definitionID, s.client.APIVersion(BuildDefinitionsAPI)))
+}
+
+// GetRevision returns a build definition as it was at a revision
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/definitions/get
+func (s *BuildDefinitionsService) GetRevision(ctx context.Context, definitionID, revision int) (*BuildDefinition, error) {
+	return s.get(ctx, fmt.Sprintf(
+		"_apis/build/definitions/%d?revision=%d&api-version=%s",
+		definitionID,
+		revision,
+		s.client.APIVersion(BuildDefinitionsAPI),
+	))
+}
+
+func (s *BuildDefinitionsService) get(ctx context.Context, URL string) (*BuildDefinition, error) {
+	request, err := s.client.NewRequestWithContext(ctx, "GET", URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	var response BuildDefinition
+	_, err = s.client.Execute(request, &response)
+
+	return &response, err
+}
+
+// BuildDefinitionCreateOptions describes what the request to the API should look like
+type BuildDefinitionCreateOptions struct {
+	DefinitionToCloneID       int `url:"definitionToCloneId,omitempty"`
+	DefinitionToCloneRevision int `url:"definitionToCloneRevision,omitempty"`
+}
+
+// Create creates a build definition and returns it as saved
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/definitions/create
+func (s *BuildDefinitionsService) Create(ctx context.Context, definition *BuildDefinition, opts *BuildDefinitionCreateOptions) (*BuildDefinition, error) {
+	URL := fmt.Sprintf("_apis/build/definitions?api-version=%s", s.client.APIVersion(BuildDefinitionsAPI))
+	URL, err := addOptions(URL, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := s.client.NewRequestWithContext(ctx, "POST", URL, definition)
+	if err != nil {
+		return nil, err
+	}
+	var response BuildDefinition
+	_, err = s.client.Execute(request, &response)
+
+	return &response, err
+}
+
+// Update replaces a build definition and returns it as saved, with its new
+// revision. definition.Revision must be the revision it was read at, and
+// ErrRevisionConflict is returned if it has been changed since
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/definitions/update
+func (s *BuildDefinitionsService) Update(ctx context.Context, definition *BuildDefinition) (*BuildDefinition, error) {
+	if definition.ID == 0 || definition.Revision == 0 {
+		return nil, errors.New("azuredevops: updating a build definition needs its ID and revision")
+	}
+
+	return s.update(ctx, definition.ID, definition)
+}
+
+// update saves body as the definition with the given ID
+func (s *BuildDefinitionsService) update(ctx context.Context, definitionID int, body interface{}) (*BuildDefinition, error) {
+	URL := fmt.Sprintf("_apis/build/definitions/%d?api-version=%s", definitionID, s.client.APIVersion(BuildDefinitionsAPI))
+
+	request, err := s.client.NewRequestWithContext(ctx, "PUT", URL, body)
+	if err != nil {
+		return nil, err
+	}
+	var response BuildDefinition
+	_, err = s.client.Execute(request, &response)
+	if isRevisionConflict(err) {
+		return nil, fmt.Errorf("%w: %w", ErrRevisionConflict, err)
+	}
+
+	return &response, err
+}
+
+// isRevisionConflict reports whether err is the API rejecting an update
+// made to an old revision
+func isRevisionConflict(err error) bool {
+	var e *ErrorResponse
+	if !errors.As(err, &e) {
+		return false
+	}
+	return e.StatusCode == http.StatusConflict ||
+		(e.StatusCode == http.StatusBadRequest && strings.Contains(strings.ToLower(e.TypeKey), "revision"))
+}
+
+// Delete deletes a build definition. It can be brought back with Restore
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/definitions/delete
+func (s *BuildDefinitionsService) Delete(ctx context.Context, definitionID int) error {
+	URL := fmt.Sprintf("_apis/build/definitions/%d?api-version=%s", definitionID, s.client.APIVersion(BuildDefinitionsAPI))
+
+	request, err := s.client.NewRequestWithContext(ctx, "DELETE", URL, nil)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.Execute(request, nil)
+
+	return err
+}
+
+// Restore brings back a deleted build definition
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/definitions/restore%20definition
+func (s *BuildDefinitionsService) Restore(ctx context.Context, definitionID int) (*BuildDefinition, error) {
+	URL := fmt.Sprintf("_apis/build/definitions/%d?deleted=false&api-version=%s", definitionID, s.client.APIVersion(BuildDefinitionsAPI))
+
+	request, err := s.client.NewRequestWithContext(ctx, "PATCH", URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	var response BuildDefinition
+	_, err = s.client.Execute(request, &response)
+
+	return &response, err
+}
+
+// ListRevisions returns the history of changes to a build definition
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/definitions/get%20definition%20revisions
+func (s *BuildDefinitionsService) ListRevisions(ctx context.Context, definitionID int) ([]BuildDefinitionRevision, error) {
+	URL := fmt.Sprintf("_apis/build/definitions/%d/revisions?api-version=%s", definitionID, s.client.APIVersion(BuildDefinitionsAPI))
+
+	request, err := s.client.NewRequestWithContext(ctx, "GET", URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	var response BuildDefinitionRevisionsResponse
 	_, err = s.client.Execute(request, &response)
 
-	return response.BuildDefinitions, err
+	return response.Revisions, err
 }
@@ -0,0 +1,317 @@
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefinitionMutation changes a build definition in place. Leaving it as it
+// was skips the definition, and returning an error fails it. BulkUpdate
+// calls it for one definition at a time, so it needn't be safe for
+// concurrent use
+type DefinitionMutation func(definition *BuildDefinition) error
+
+// BulkUpdateOptions controls BuildDefinitionsService.BulkUpdate
+type BulkUpdateOptions struct {
+	// List selects the definitions to consider. IncludeAllProperties is
+	// always set, as updates need the whole definition
+	List BuildDefinitionsListOptions
+	// Filter narrows the listed definitions down, nil keeps them all
+	Filter func(definition BuildDefinition) bool
+	// DryRun works out the changes without saving them
+	DryRun bool
+	// Concurrency is how many definitions are updated at once, default 4
+	Concurrency int
+	// ConflictRetries is how many times a definition changed by someone
+	// else since it was read is fetched again and mutated afresh. By default
+	// the conflict is reported as a failure
+	ConflictRetries int
+}
+
+// BulkUpdateResult is the outcome of BulkUpdate for one definition
+type BulkUpdateResult struct {
+	// Definition is the definition as it was listed
+	Definition BuildDefinition
+	// Diff is the change the mutation made, empty if it made none
+	Diff DefinitionDiff
+	// Updated is the definition as saved, nil for a dry run, a definition
+	// left unchanged or a failure
+	Updated *BuildDefinition
+	Err     error
+}
+
+// Changed reports whether the mutation changed the definition
+func (r BulkUpdateResult) Changed() bool {
+	return len(r.Diff) > 0
+}
+
+// DefinitionChange is a single difference between two build definitions
+type DefinitionChange struct {
+	// Op is add, remove or replace
+	Op string
+	// Path is the JSON pointer to the field, e.g. /variables/env/value
+	Path string
+	Old  interface{}
+	New  interface{}
+}
+
+// String describes the change, e.g. replace /queue/name: "a" -> "b"
+func (c DefinitionChange) String() string {
+	switch c.Op {
+	case "add":
+		return fmt.Sprintf("add %s: %s", c.Path, diffValue(c.New))
+	case "remove":
+		return fmt.Sprintf("remove %s: %s", c.Path, diffValue(c.Old))
+	default:
+		return fmt.Sprintf("%s %s: %s -> %s", c.Op, c.Path, diffValue(c.Old), diffValue(c.New))
+	}
+}
+
+// DefinitionDiff is the list of changes between two build definitions,
+// ordered by path
+type DefinitionDiff []DefinitionChange
+
+// String describes every change, one per line
+func (d DefinitionDiff) String() string {
+	lines := make([]string, len(d))
+	for i, change := range d {
+		lines[i] = change.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// DiffDefinitions returns the changes that turn before into after, compared
+// field by field as they are sent to the API. Fields the API sent that
+// BuildDefinition doesn't model are compared too
+func DiffDefinitions(before, after *BuildDefinition) (DefinitionDiff, error) {
+	a, err := json.Marshal(before)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(after)
+	if err != nil {
+		return nil, err
+	}
+	return diffDocuments(a, b)
+}
+
+// diffDocuments returns the changes that turn the JSON document a into b
+func diffDocuments(a, b []byte) (DefinitionDiff, error) {
+	valueA, err := decodeJSON(a)
+	if err != nil {
+		return nil, err
+	}
+	valueB, err := decodeJSON(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var diff DefinitionDiff
+	diffJSON("", valueA, valueB, &diff)
+	return diff, nil
+}
+
+// BulkUpdate applies mutate to every definition selected by opts and saves
+// the ones it changes, several at a time. Each save is made against the
+// revision that was read, so a definition changed by someone else in the
+// meantime is never overwritten. The results are in the order the
+// definitions were listed, and only a failure to list them is returned as
+// an error
+func (s *BuildDefinitionsService) BulkUpdate(ctx context.Context, mutate DefinitionMutation, opts *BulkUpdateOptions) ([]BulkUpdateResult, error) {
+	if opts == nil {
+		opts = &BulkUpdateOptions{}
+	}
+
+	listOpts := opts.List
+	listOpts.IncludeAllProperties = true
+	definitions, err := s.ListAll(ctx, &listOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []BulkUpdateResult
+	for _, definition := range definitions {
+		if opts.Filter == nil || opts.Filter(definition) {
+			results = append(results, BulkUpdateResult{Definition: definition})
+		}
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 4
+	}
+
+	// The mutations are made one at a time, only the requests overlap
+	var mu sync.Mutex
+	serialMutate := func(definition *BuildDefinition) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return mutate(definition)
+	}
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, concurrency)
+	for i := range results {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(result *BulkUpdateResult) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			s.bulkUpdate(ctx, result, serialMutate, opts)
+		}(&results[i])
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// bulkUpdate mutates and saves a single definition for BulkUpdate
+func (s *BuildDefinitionsService) bulkUpdate(ctx context.Context, result *BulkUpdateResult, mutate DefinitionMutation, opts *BulkUpdateOptions) {
+	current := result.Definition
+	for attempt := 0; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			result.Err = err
+			return
+		}
+
+		// The mutation works on a copy decoded from the JSON the API sent,
+		// and the diff is between that JSON and the body that is sent back
+		before, err := definitionJSON(&current)
+		if err != nil {
+			result.Err = err
+			return
+		}
+		var mutated BuildDefinition
+		if err := json.Unmarshal(before, &mutated); err != nil {
+			result.Err = err
+			return
+		}
+		if err := mutate(&mutated); err != nil {
+			result.Err = err
+			return
+		}
+
+		// The mutation must not be able to move the update onto another
+		// definition or revision
+		mutated.ID = current.ID
+		mutated.Revision = current.Revision
+
+		body, err := json.Marshal(&mutated)
+		if err != nil {
+			result.Err = err
+			return
+		}
+		diff, err := diffDocuments(before, body)
+		if err != nil {
+			result.Err = err
+			return
+		}
+		result.Diff = diff
+		if len(diff) == 0 || opts.DryRun {
+			return
+		}
+
+		updated, err := s.update(ctx, current.ID, json.RawMessage(body))
+		if err == nil {
+			result.Updated = updated
+			return
+		}
+		if !errors.Is(err, ErrRevisionConflict) || attempt >= opts.ConflictRetries {
+			result.Err = err
+			return
+		}
+
+		latest, err := s.Get(ctx, current.ID)
+		if err != nil {
+			result.Err = err
+			return
+		}
+		current = *latest
+	}
+}
+
+// definitionJSON returns the JSON the API sent for d, or d encoded if it
+// wasn't read from the API
+func definitionJSON(d *BuildDefinition) ([]byte, error) {
+	if d.raw != nil {
+		return d.raw, nil
+	}
+	return json.Marshal(d)
+}
+
+// diffJSON appends the differences between two decoded JSON values at path
+func diffJSON(path string, a, b interface{}, diff *DefinitionDiff) {
+	objectA, okA := a.(map[string]interface{})
+	objectB, okB := b.(map[string]interface{})
+	if okA && okB {
+		keys := map[string]bool{}
+		for key := range objectA {
+			keys[key] = true
+		}
+		for key := range objectB {
+			keys[key] = true
+		}
+		sorted := make([]string, 0, len(keys))
+		for key := range keys {
+			sorted = append(sorted, key)
+		}
+		sort.Strings(sorted)
+
+		for _, key := range sorted {
+			valueA, inA := objectA[key]
+			valueB, inB := objectB[key]
+			child := path + propertyPath(key)
+			switch {
+			case !inA:
+				*diff = append(*diff, DefinitionChange{Op: "add", Path: child, New: valueB})
+			case !inB:
+				*diff = append(*diff, DefinitionChange{Op: "remove", Path: child, Old: valueA})
+			default:
+				diffJSON(child, valueA, valueB, diff)
+			}
+		}
+		return
+	}
+
+	arrayA, okA := a.([]interface{})
+	arrayB, okB := b.([]interface{})
+	if okA && okB {
+		for i := 0; i < len(arrayA) || i < len(arrayB); i++ {
+			child := fmt.Sprintf("%s/%d", path, i)
+			switch {
+			case i >= len(arrayA):
+				*diff = append(*diff, DefinitionChange{Op: "add", Path: child, New: arrayB[i]})
+			case i >= len(arrayB):
+				*diff = append(*diff, DefinitionChange{Op: "remove", Path: child, Old: arrayA[i]})
+			default:
+				diffJSON(child, arrayA[i], arrayB[i], diff)
+			}
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		*diff = append(*diff, DefinitionChange{Op: "replace", Path: path, Old: a, New: b})
+	}
+}
+
+// diffValue formats a value of a DefinitionChange as JSON
+func diffValue(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
@@ -0,0 +1,274 @@
+package azuredevops_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/benmatselby/go-azuredevops/azuredevops"
+	"github.com/benmatselby/go-azuredevops/azuredevops/azuredevopstest"
+)
+
+// seedDefinitions adds definitions on the Default queue, one of which
+// already uses the Hosted queue
+func seedDefinitions(server *azuredevopstest.Server) {
+	for _, name := range []string{"api", "web", "worker"} {
+		queue := "Default"
+		if name == "worker" {
+			queue = "Hosted"
+		}
+		server.AddDefinition(azuredevops.BuildDefinition{
+			Name:  name,
+			Path:  `\services`,
+			Queue: &azuredevops.AgentPoolQueue{Name: queue},
+			Variables: map[string]azuredevops.BuildDefinitionVariable{
+				"token": {IsSecret: true},
+			},
+		})
+	}
+	server.AddDefinition(azuredevops.BuildDefinition{Name: "docs", Path: `\`})
+}
+
+// useHostedQueue moves a definition to the Hosted queue and sets a variable
+func useHostedQueue(definition *azuredevops.BuildDefinition) error {
+	definition.Queue = &azuredevops.AgentPoolQueue{Name: "Hosted"}
+	if definition.Variables == nil {
+		definition.Variables = map[string]azuredevops.BuildDefinitionVariable{}
+	}
+	definition.Variables["region"] = azuredevops.BuildDefinitionVariable{Value: "westeurope"}
+	return nil
+}
+
+func TestDiffDefinitions(t *testing.T) {
+	before := &azuredevops.BuildDefinition{
+		Name:  "api",
+		Queue: &azuredevops.AgentPoolQueue{Name: "Default"},
+		Tags:  []string{"a", "b"},
+	}
+	after := &azuredevops.BuildDefinition{
+		Name:        "api",
+		Description: "The API",
+		Queue:       &azuredevops.AgentPoolQueue{Name: "Hosted"},
+		Tags:        []string{"a"},
+	}
+
+	diff, err := azuredevops.DiffDefinitions(before, after)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	expected := strings.Join([]string{
+		`add /description: "The API"`,
+		`replace /queue/name: "Default" -> "Hosted"`,
+		`remove /tags/1: "b"`,
+	}, "\n")
+	if diff.String() != expected {
+		t.Fatalf("expected diff\n%s\ngot\n%s", expected, diff)
+	}
+}
+
+func TestBuildDefinitionsService_BulkUpdateDryRun(t *testing.T) {
+	server := azuredevopstest.NewServer("AZURE_DEVOPS_Project")
+	defer server.Close()
+	seedDefinitions(server)
+
+	results, err := server.Client().BuildDefinitions.BulkUpdate(context.Background(), useHostedQueue, &azuredevops.BulkUpdateOptions{
+		List:   azuredevops.BuildDefinitionsListOptions{Path: `\services`},
+		DryRun: true,
+	})
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("expected a result for each definition in the folder; got %d", len(results))
+	}
+
+	if results[0].Definition.Name != "api" || results[0].Diff.String() != "replace /queue/name: \"Default\" -> \"Hosted\"\nadd /variables/region: {\"value\":\"westeurope\"}" {
+		t.Fatalf("expected the queue and variable changes for api; got\n%s", results[0].Diff)
+	}
+
+	for _, result := range results {
+		if result.Updated != nil || result.Err != nil {
+			t.Fatalf("expected nothing to be saved on a dry run; got %+v", result)
+		}
+	}
+
+	for _, request := range server.Requests() {
+		if request.Method != "GET" {
+			t.Fatalf("expected only GET requests on a dry run; got %s %s", request.Method, request.URL)
+		}
+	}
+}
+
+func TestBuildDefinitionsService_BulkUpdate(t *testing.T) {
+	server := azuredevopstest.NewServer("AZURE_DEVOPS_Project")
+	defer server.Close()
+	seedDefinitions(server)
+
+	var running int32
+	mutate := func(definition *azuredevops.BuildDefinition) error {
+		if atomic.AddInt32(&running, 1) > 1 {
+			t.Errorf("expected the mutations to be made one at a time")
+		}
+		defer atomic.AddInt32(&running, -1)
+
+		if definition.Name == "web" {
+			return errors.New("web is frozen")
+		}
+		if definition.Queue.Name == "Hosted" {
+			return nil
+		}
+		return useHostedQueue(definition)
+	}
+
+	results, err := server.Client().BuildDefinitions.BulkUpdate(context.Background(), mutate, &azuredevops.BulkUpdateOptions{
+		Filter:      func(definition azuredevops.BuildDefinition) bool { return definition.Queue != nil },
+		Concurrency: 2,
+	})
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("expected the definitions with a queue; got %d", len(results))
+	}
+
+	api, web, worker := results[0], results[1], results[2]
+	if api.Err != nil || api.Updated == nil || api.Updated.Revision != 2 {
+		t.Fatalf("expected api to be saved as revision 2; got %+v", api)
+	}
+
+	if web.Err == nil || web.Err.Error() != "web is frozen" || web.Updated != nil {
+		t.Fatalf("expected web to fail; got %+v", web)
+	}
+
+	if worker.Err != nil || worker.Changed() || worker.Updated != nil {
+		t.Fatalf("expected worker to be left alone; got %+v", worker)
+	}
+
+	saved := server.Definitions()[0]
+	if saved.Queue.Name != "Hosted" || saved.Variables["region"].Value != "westeurope" {
+		t.Fatalf("expected api to be on the Hosted queue; got %+v", saved)
+	}
+
+	if !saved.Variables["token"].IsSecret {
+		t.Fatalf("expected the secret variable to be kept; got %+v", saved.Variables)
+	}
+}
+
+func TestBuildDefinitionsService_BulkUpdateKeepsUnmodelledFields(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	payload, err := os.ReadFile(filepath.Join("testdata", "build_definition.json"))
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	mux.HandleFunc(buildDefinitionListURL, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"count": 1, "value": [%s]}`, payload)
+	})
+
+	var body []byte
+	mux.HandleFunc(buildDefinitionURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		body, _ = io.ReadAll(r.Body)
+		w.Write(payload)
+	})
+
+	results, err := c.BuildDefinitions.BulkUpdate(context.Background(), func(definition *azuredevops.BuildDefinition) error {
+		definition.Description = "Builds the web app"
+		return nil
+	}, nil)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(results) != 1 || results[0].Err != nil {
+		t.Fatalf("expected the definition to be saved; got %+v", results)
+	}
+
+	if diff := results[0].Diff.String(); diff != `add /description: "Builds the web app"` {
+		t.Fatalf("expected only the description to change; got\n%s", diff)
+	}
+
+	var expected map[string]interface{}
+	if err := json.Unmarshal(payload, &expected); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	expected["description"] = "Builds the web app"
+
+	want, _ := json.Marshal(expected)
+	if got, expected := canonicalJSON(t, body), canonicalJSON(t, want); got != expected {
+		t.Fatalf("expected only the description to differ\nexpected %s\ngot      %s", expected, got)
+	}
+}
+
+func TestBuildDefinitionsService_BulkUpdateConflict(t *testing.T) {
+	tt := []struct {
+		name     string
+		retries  int
+		revision int
+	}{
+		{name: "reports the conflict", retries: 0},
+		{name: "retries against the latest revision", retries: 1, revision: 3},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			server := azuredevopstest.NewServer("AZURE_DEVOPS_Project")
+			defer server.Close()
+			server.AddDefinition(azuredevops.BuildDefinition{Name: "api"})
+
+			c := server.Client()
+			ctx := context.Background()
+
+			// Someone else saves the definition the first time it is mutated
+			calls := 0
+			mutate := func(definition *azuredevops.BuildDefinition) error {
+				calls++
+				if calls == 1 {
+					other, err := c.BuildDefinitions.Get(ctx, definition.ID)
+					if err != nil {
+						return err
+					}
+					other.Description = "changed elsewhere"
+					if _, err := c.BuildDefinitions.Update(ctx, other); err != nil {
+						return err
+					}
+				}
+				return useHostedQueue(definition)
+			}
+
+			results, err := c.BuildDefinitions.BulkUpdate(ctx, mutate, &azuredevops.BulkUpdateOptions{ConflictRetries: tc.retries})
+			if err != nil {
+				t.Fatalf("returned error: %v", err)
+			}
+
+			result := results[0]
+			if tc.retries == 0 {
+				if !errors.Is(result.Err, azuredevops.ErrRevisionConflict) {
+					t.Fatalf("expected a revision conflict; got %v", result.Err)
+				}
+				return
+			}
+
+			if result.Err != nil || result.Updated.Revision != tc.revision {
+				t.Fatalf("expected revision %d; got %+v", tc.revision, result)
+			}
+
+			if result.Updated.Description != "changed elsewhere" {
+				t.Fatalf("expected the other change to be kept; got %q", result.Updated.Description)
+			}
+		})
+	}
+}
@@ -1,8 +1,16 @@
 package azuredevops_test
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/benmatselby/go-azuredevops/azuredevops"
@@ -23,6 +31,48 @@ const (
 		],
 		"count": 2
 	}`
+	buildDefinitionURL = "/AZURE_DEVOPS_Project/_apis/build/definitions/7"
+	// https://docs.microsoft.com/en-us/rest/api/azure/devops/build/definitions/get
+	buildDefinitionResponse = `{
+		"id": 7,
+		"name": "web-app",
+		"path": "\\services",
+		"revision": 12,
+		"type": "build",
+		"quality": "definition",
+		"queueStatus": "enabled",
+		"buildNumberFormat": "$(date:yyyyMMdd)$(rev:.r)",
+		"createdDate": "2019-01-15T20:01:00.123Z",
+		"authoredBy": {"displayName": "Jamal Hartnett"},
+		"project": {"id": "eb6e4656", "name": "Fabrikam", "state": "wellFormed"},
+		"repository": {
+			"id": "278d5cd2",
+			"type": "TfsGit",
+			"name": "web-app",
+			"defaultBranch": "refs/heads/main",
+			"clean": "false",
+			"checkoutSubmodules": true
+		},
+		"process": {"type": 2, "yamlFilename": "/azure-pipelines.yml"},
+		"queue": {"id": 12, "name": "Azure Pipelines", "pool": {"id": 9, "name": "Azure Pipelines", "isHosted": true}},
+		"triggers": [
+			{"triggerType": "continuousIntegration", "branchFilters": ["+refs/heads/main"], "pathFilters": [], "batchChanges": true, "maxConcurrentBuildsPerBranch": 1, "settingsSourceType": 2}
+		],
+		"variables": {
+			"configuration": {"value": "release", "allowOverride": true},
+			"apiKey": {"value": null, "isSecret": true}
+		},
+		"variableGroups": [{"id": 3, "name": "shared"}],
+		"retentionRules": [
+			{"branches": ["+refs/heads/*"], "artifacts": ["build.SourceLabel"], "daysToKeep": 10, "minimumToKeep": 1, "deleteBuildRecord": true, "deleteTestResults": true}
+		],
+		"options": [
+			{"enabled": true, "definition": {"id": "5d58cc01-7c75-450c-be18-a388ddb129ec"}, "inputs": {"additionalFields": "{}"}}
+		],
+		"jobAuthorizationScope": "projectCollection",
+		"jobTimeoutInMinutes": 60,
+		"badgeEnabled": true
+	}`
 )
 
 func TestBuildDefinitionsService_List(t *testing.T) {
@@ -51,7 +101,7 @@ func TestBuildDefinitionsService_List(t *testing.T) {
 			})
 
 			options := &azuredevops.BuildDefinitionsListOptions{}
-			buildDefs, err := c.BuildDefinitions.List(options)
+			buildDefs, _, err := c.BuildDefinitions.List(context.Background(), options)
 			if err != nil {
 				t.Fatalf("returned error: %v", err)
 			}
@@ -72,3 +122,322 @@ func TestBuildDefinitionsService_List(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildDefinitionsService_Get(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(buildDefinitionURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testURL(t, r, buildDefinitionURL+"?api-version=5.0-preview.6")
+		fmt.Fprint(w, buildDefinitionResponse)
+	})
+
+	definition, err := c.BuildDefinitions.Get(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if definition.Revision != 12 || definition.Path != "\\services" {
+		t.Fatalf("expected revision 12 in \\services; got %d in %s", definition.Revision, definition.Path)
+	}
+
+	if definition.Process.Type != azuredevops.YAMLProcess || definition.Process.YAMLFilename != "/azure-pipelines.yml" {
+		t.Fatalf("expected a YAML process; got %v", definition.Process)
+	}
+
+	if definition.Repository.DefaultBranch != "refs/heads/main" || !definition.Repository.CheckoutSubmodules {
+		t.Fatalf("expected the repository settings; got %v", definition.Repository)
+	}
+
+	if len(definition.Triggers) != 1 || definition.Triggers[0].BranchFilters[0] != "+refs/heads/main" {
+		t.Fatalf("expected the CI trigger; got %v", definition.Triggers)
+	}
+
+	if definition.Variables["configuration"].Value != "release" || !definition.Variables["apiKey"].IsSecret {
+		t.Fatalf("expected the variables; got %v", definition.Variables)
+	}
+
+	if definition.Queue.Pool == nil || !definition.Queue.Pool.IsHosted {
+		t.Fatalf("expected a hosted queue; got %v", definition.Queue)
+	}
+
+	if len(definition.RetentionRules) != 1 || definition.RetentionRules[0].DaysToKeep != 10 {
+		t.Fatalf("expected the retention rule; got %v", definition.RetentionRules)
+	}
+
+	if len(definition.Options) != 1 || !definition.Options[0].Enabled || definition.CreatedDate.IsZero() {
+		t.Fatalf("expected the options and created date; got %v", definition)
+	}
+}
+
+func TestBuildDefinitionsService_GetRevision(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(buildDefinitionURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testURL(t, r, buildDefinitionURL+"?revision=3&api-version=5.0-preview.6")
+		fmt.Fprint(w, `{"id": 7, "revision": 3}`)
+	})
+
+	definition, err := c.BuildDefinitions.GetRevision(context.Background(), 7, 3)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if definition.Revision != 3 {
+		t.Fatalf("expected revision 3; got %d", definition.Revision)
+	}
+}
+
+func TestBuildDefinitionsService_Create(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(buildDefinitionListURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testURL(t, r, buildDefinitionListURL+"?api-version=5.0-preview.6&definitionToCloneId=3")
+		testBody(t, r, `{"name":"web-app","process":{"type":2,"yamlFilename":"/azure-pipelines.yml"}}`+"\n")
+		fmt.Fprint(w, `{"id": 8, "name": "web-app", "revision": 1}`)
+	})
+
+	definition := &azuredevops.BuildDefinition{
+		Name:    "web-app",
+		Process: &azuredevops.BuildProcess{Type: azuredevops.YAMLProcess, YAMLFilename: "/azure-pipelines.yml"},
+	}
+	created, err := c.BuildDefinitions.Create(context.Background(), definition, &azuredevops.BuildDefinitionCreateOptions{DefinitionToCloneID: 3})
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if created.ID != 8 || created.Revision != 1 {
+		t.Fatalf("expected definition 8 at revision 1; got %d at %d", created.ID, created.Revision)
+	}
+}
+
+func TestBuildDefinitionsService_Update(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(buildDefinitionURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		var definition azuredevops.BuildDefinition
+		if err := json.NewDecoder(r.Body).Decode(&definition); err != nil {
+			t.Fatalf("returned error: %v", err)
+		}
+
+		if string(definition.Process.Phases) != `[{"name":"Phase 1","steps":[]}]` {
+			t.Errorf("expected the designer phases to be sent unchanged; got %s", definition.Process.Phases)
+		}
+
+		if definition.Revision != 12 {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"message": "The definition has been updated.", "typeKey": "DefinitionRevisionConflictException"}`)
+			return
+		}
+
+		definition.Revision++
+		json.NewEncoder(w).Encode(definition)
+	})
+
+	definition := &azuredevops.BuildDefinition{
+		ID:       7,
+		Revision: 12,
+		Process:  &azuredevops.BuildProcess{Type: azuredevops.DesignerProcess, Phases: json.RawMessage(`[{"name":"Phase 1","steps":[]}]`)},
+	}
+
+	updated, err := c.BuildDefinitions.Update(context.Background(), definition)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if updated.Revision != 13 {
+		t.Fatalf("expected revision 13; got %d", updated.Revision)
+	}
+
+	definition.Revision = 11
+	_, err = c.BuildDefinitions.Update(context.Background(), definition)
+	if !errors.Is(err, azuredevops.ErrRevisionConflict) || !azuredevops.IsConflict(err) {
+		t.Fatalf("expected a revision conflict; got %v", err)
+	}
+
+	_, err = c.BuildDefinitions.Update(context.Background(), &azuredevops.BuildDefinition{ID: 7})
+	if err == nil || !strings.Contains(err.Error(), "revision") {
+		t.Fatalf("expected an error for a definition without a revision; got %v", err)
+	}
+}
+
+// canonicalJSON re-encodes data with sorted keys and exact numbers, so two
+// documents can be compared byte for byte
+func canonicalJSON(t *testing.T, data []byte) string {
+	t.Helper()
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	return buf.String()
+}
+
+func TestBuildDefinition_RoundTrip(t *testing.T) {
+	payload, err := os.ReadFile(filepath.Join("testdata", "build_definition.json"))
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	var definition azuredevops.BuildDefinition
+	if err := json.Unmarshal(payload, &definition); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	data, err := json.Marshal(definition)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if got, expected := canonicalJSON(t, data), canonicalJSON(t, payload); got != expected {
+		t.Fatalf("expected the definition to be encoded as it was decoded\nexpected %s\ngot      %s", expected, got)
+	}
+}
+
+func TestBuildDefinitionsService_UpdateKeepsUnmodelledFields(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	payload, err := os.ReadFile(filepath.Join("testdata", "build_definition.json"))
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	var body []byte
+	mux.HandleFunc(buildDefinitionURL, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			body, _ = io.ReadAll(r.Body)
+		}
+		w.Write(payload)
+	})
+
+	definition, err := c.BuildDefinitions.Get(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	definition.Description = "Builds the web app"
+	definition.Triggers[1].BranchFilters = append(definition.Triggers[1].BranchFilters, "+refs/heads/release/*")
+	definition.Variables["BuildPlatform"] = azuredevops.BuildDefinitionVariable{Value: "x64"}
+	delete(definition.Variables, "BuildConfiguration")
+	definition.BadgeEnabled = true
+
+	if _, err := c.BuildDefinitions.Update(context.Background(), definition); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	// The same changes made to the JSON the API sent
+	var expected map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	if err := decoder.Decode(&expected); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	expected["description"] = "Builds the web app"
+	expected["badgeEnabled"] = true
+	trigger := expected["triggers"].([]interface{})[1].(map[string]interface{})
+	trigger["branchFilters"] = append(trigger["branchFilters"].([]interface{}), "+refs/heads/release/*")
+	variables := expected["variables"].(map[string]interface{})
+	variables["BuildPlatform"] = map[string]interface{}{"value": "x64"}
+	delete(variables, "BuildConfiguration")
+
+	want, _ := json.Marshal(expected)
+	if got, expected := canonicalJSON(t, body), canonicalJSON(t, want); got != expected {
+		t.Fatalf("expected only the changed fields to differ\nexpected %s\ngot      %s", expected, got)
+	}
+}
+
+func TestBuildDefinitionsService_DeleteAndRestore(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(buildDefinitionURL, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		case "PATCH":
+			testURL(t, r, buildDefinitionURL+"?deleted=false&api-version=5.0-preview.6")
+			fmt.Fprint(w, `{"id": 7, "name": "web-app"}`)
+		default:
+			t.Errorf("unexpected %s", r.Method)
+		}
+	})
+
+	if err := c.BuildDefinitions.Delete(context.Background(), 7); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	definition, err := c.BuildDefinitions.Restore(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if definition.Name != "web-app" {
+		t.Fatalf("expected the restored definition; got %v", definition)
+	}
+}
+
+func TestBuildDefinitionsService_ListRevisions(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(buildDefinitionURL+"/revisions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+			"count": 2,
+			"value": [
+				{"revision": 1, "name": "web-app", "changeType": "add", "changedDate": "2019-01-15T20:01:00Z", "changedBy": {"displayName": "Jamal Hartnett"}},
+				{"revision": 2, "name": "web-app", "changeType": "update", "changedDate": "2019-01-16T09:30:00Z", "comment": "Run the tests"}
+			]
+		}`)
+	})
+
+	revisions, err := c.BuildDefinitions.ListRevisions(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(revisions) != 2 || revisions[1].ChangeType != "update" || revisions[1].Comment != "Run the tests" {
+		t.Fatalf("expected 2 revisions; got %v", revisions)
+	}
+
+	if revisions[0].ChangedBy.DisplayName != "Jamal Hartnett" {
+		t.Fatalf("expected the author of revision 1; got %v", revisions[0].ChangedBy)
+	}
+}
+
+func TestBuildDefinitionVariable_MarshalJSON(t *testing.T) {
+	variables := map[string]azuredevops.BuildDefinitionVariable{
+		"apiKey":        {IsSecret: true},
+		"configuration": {Value: "release", AllowOverride: true},
+		"empty":         {},
+		"password":      {Value: "hunter2", IsSecret: true},
+	}
+
+	data, err := json.Marshal(variables)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	expected := `{"apiKey":{"value":null,"isSecret":true},"configuration":{"value":"release","allowOverride":true},"empty":{"value":""},"password":{"value":"hunter2","isSecret":true}}`
+	if string(data) != expected {
+		t.Fatalf("expected %s; got %s", expected, data)
+	}
+}
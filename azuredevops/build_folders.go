@@ -0,0 +1,204 @@
+package azuredevops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// RootFolder is the path of the folder every build definition lives under
+const RootFolder = `\`
+
+// Folder is a folder of build definitions. Paths are separated by
+// backslashes, e.g. \services\api
+type Folder struct {
+	Path            string                `json:"path"`
+	Description     string                `json:"description,omitempty"`
+	CreatedBy       *IdentityRef          `json:"createdBy,omitempty"`
+	CreatedOn       *Time                 `json:"createdOn,omitempty"`
+	LastChangedBy   *IdentityRef          `json:"lastChangedBy,omitempty"`
+	LastChangedDate *Time                 `json:"lastChangedDate,omitempty"`
+	Project         *TeamProjectReference `json:"project,omitempty"`
+}
+
+// FoldersResponse is the wrapper around the main response for the List of folders
+type FoldersResponse struct {
+	Count   int      `json:"count"`
+	Folders []Folder `json:"value"`
+}
+
+// folderURL returns the folders URL for path
+func (s *BuildDefinitionsService) folderURL(path string) string {
+	return fmt.Sprintf(
+		"_apis/build/folders/%s?api-version=%s",
+		url.PathEscape(cleanFolderPath(path)),
+		s.client.APIVersion(BuildFoldersAPI),
+	)
+}
+
+// ListFolders returns the folder at path and every folder below it. An
+// empty path lists every folder in the project
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/folders/list
+func (s *BuildDefinitionsService) ListFolders(ctx context.Context, path string) ([]Folder, error) {
+	request, err := s.client.NewRequestWithContext(ctx, "GET", s.folderURL(path), nil)
+	if err != nil {
+		return nil, err
+	}
+	var response FoldersResponse
+	_, err = s.client.Execute(request, &response)
+
+	return response.Folders, err
+}
+
+// CreateFolder creates the folder at path
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/folders/create
+func (s *BuildDefinitionsService) CreateFolder(ctx context.Context, path, description string) (*Folder, error) {
+	folder := Folder{Path: cleanFolderPath(path), Description: description}
+
+	request, err := s.client.NewRequestWithContext(ctx, "PUT", s.folderURL(path), folder)
+	if err != nil {
+		return nil, err
+	}
+	var response Folder
+	_, err = s.client.Execute(request, &response)
+
+	return &response, err
+}
+
+// DeleteFolder deletes the folder at path. The API deletes every folder and
+// build definition below it too
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/folders/delete
+func (s *BuildDefinitionsService) DeleteFolder(ctx context.Context, path string) error {
+	if cleanFolderPath(path) == RootFolder {
+		return errors.New("azuredevops: the root folder cannot be deleted")
+	}
+
+	request, err := s.client.NewRequestWithContext(ctx, "DELETE", s.folderURL(path), nil)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.Execute(request, nil)
+
+	return err
+}
+
+// FolderTree lists every folder and build definition in the project and
+// returns them as a tree, see NewFolderTree
+func (s *BuildDefinitionsService) FolderTree(ctx context.Context) (*FolderNode, error) {
+	folders, err := s.ListFolders(ctx, RootFolder)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions, err := s.ListAll(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFolderTree(folders, definitions), nil
+}
+
+// FolderNode is a folder in a tree of build definitions
+type FolderNode struct {
+	// Name is the last part of Path, and empty for the root
+	Name string
+	Path string
+	// Folder is nil for the root and for folders only known from the path
+	// of a definition
+	Folder      *Folder
+	Folders     []*FolderNode
+	Definitions []BuildDefinition
+}
+
+// NewFolderTree arranges folders and definitions into a tree rooted at
+// RootFolder, the way the web UI shows them. Folders missing from folders
+// but in the path of a definition are added. Paths are compared ignoring
+// case and each folder's children are sorted by name
+func NewFolderTree(folders []Folder, definitions []BuildDefinition) *FolderNode {
+	root := &FolderNode{Path: RootFolder}
+	nodes := map[string]*FolderNode{folderKey(RootFolder): root}
+
+	// node returns the node for path, creating it and its parents as needed
+	var node func(path string) *FolderNode
+	node = func(path string) *FolderNode {
+		path = cleanFolderPath(path)
+		if n, ok := nodes[folderKey(path)]; ok {
+			return n
+		}
+
+		i := strings.LastIndex(path, `\`)
+		parent := node(path[:i])
+		n := &FolderNode{Name: path[i+1:], Path: path}
+		parent.Folders = append(parent.Folders, n)
+		nodes[folderKey(path)] = n
+		return n
+	}
+
+	for i := range folders {
+		n := node(folders[i].Path)
+		if n != root {
+			n.Folder = &folders[i]
+		}
+	}
+	for _, definition := range definitions {
+		n := node(definition.Path)
+		n.Definitions = append(n.Definitions, definition)
+	}
+
+	root.Walk(func(n *FolderNode, depth int) {
+		sort.SliceStable(n.Folders, func(i, j int) bool {
+			return strings.ToLower(n.Folders[i].Name) < strings.ToLower(n.Folders[j].Name)
+		})
+		sort.SliceStable(n.Definitions, func(i, j int) bool {
+			return strings.ToLower(n.Definitions[i].Name) < strings.ToLower(n.Definitions[j].Name)
+		})
+	})
+
+	return root
+}
+
+// Find returns the node at path below n, or nil if there is none
+func (n *FolderNode) Find(path string) *FolderNode {
+	key := folderKey(path)
+
+	var found *FolderNode
+	n.Walk(func(node *FolderNode, depth int) {
+		if found == nil && folderKey(node.Path) == key {
+			found = node
+		}
+	})
+	return found
+}
+
+// Walk calls fn for n and every folder below it, parents before their
+// children. depth is 0 for n
+func (n *FolderNode) Walk(fn func(node *FolderNode, depth int)) {
+	n.walk(fn, 0)
+}
+
+func (n *FolderNode) walk(fn func(node *FolderNode, depth int), depth int) {
+	fn(n, depth)
+	for _, child := range n.Folders {
+		child.walk(fn, depth+1)
+	}
+}
+
+// cleanFolderPath makes path absolute, accepting forward slashes, and
+// removes empty segments and any trailing backslash
+func cleanFolderPath(path string) string {
+	var segments []string
+	for _, segment := range strings.FieldsFunc(path, func(r rune) bool { return r == '\\' || r == '/' }) {
+		if segment = strings.TrimSpace(segment); segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return RootFolder + strings.Join(segments, `\`)
+}
+
+// folderKey is the key paths are compared by
+func folderKey(path string) string {
+	return strings.ToLower(cleanFolderPath(path))
+}
@@ -0,0 +1,133 @@
+package azuredevops_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/benmatselby/go-azuredevops/azuredevops"
+)
+
+const (
+	buildFoldersURL = "/AZURE_DEVOPS_Project/_apis/build/folders/"
+	// https://docs.microsoft.com/en-us/rest/api/azure/devops/build/folders/list
+	buildFoldersResponse = `{
+		"count": 2,
+		"value": [
+			{"path": "\\services", "description": "Back end services", "createdOn": "2019-01-15T20:01:00.123Z", "createdBy": {"displayName": "Jamal Hartnett"}},
+			{"path": "\\services\\api"}
+		]
+	}`
+)
+
+func TestBuildDefinitionsService_ListFolders(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(buildFoldersURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testURL(t, r, buildFoldersURL+"%5Cservices?api-version=5.0-preview.2")
+		fmt.Fprint(w, buildFoldersResponse)
+	})
+
+	folders, err := c.BuildDefinitions.ListFolders(context.Background(), "services/")
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(folders) != 2 {
+		t.Fatalf("expected 2 folders; got %d", len(folders))
+	}
+
+	if folders[1].Path != `\services\api` || folders[0].CreatedBy.DisplayName != "Jamal Hartnett" {
+		t.Fatalf("expected the folders to be decoded; got %+v", folders)
+	}
+}
+
+func TestBuildDefinitionsService_CreateFolder(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(buildFoldersURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		testURL(t, r, buildFoldersURL+"%5Cservices%5Cweb?api-version=5.0-preview.2")
+		testBody(t, r, `{"path":"\\services\\web","description":"Front ends"}`+"\n")
+		fmt.Fprint(w, `{"path": "\\services\\web", "description": "Front ends"}`)
+	})
+
+	folder, err := c.BuildDefinitions.CreateFolder(context.Background(), `\services\web`, "Front ends")
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if folder.Path != `\services\web` {
+		t.Fatalf("expected the created folder; got %+v", folder)
+	}
+}
+
+func TestBuildDefinitionsService_DeleteFolder(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(buildFoldersURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		testURL(t, r, buildFoldersURL+"%5Cservices?api-version=5.0-preview.2")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := c.BuildDefinitions.DeleteFolder(context.Background(), `\services`); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if err := c.BuildDefinitions.DeleteFolder(context.Background(), `\`); err == nil {
+		t.Fatalf("expected an error deleting the root folder")
+	}
+}
+
+func TestNewFolderTree(t *testing.T) {
+	folders := []azuredevops.Folder{
+		{Path: `\services`, Description: "Back end services"},
+		{Path: `\services\api`},
+		{Path: `\Archive`},
+	}
+	definitions := []azuredevops.BuildDefinition{
+		{ID: 1, Name: "web-app", Path: `\services\web`},
+		{ID: 2, Name: "gateway", Path: `\Services\API`},
+		{ID: 3, Name: "docs", Path: `\`},
+		{ID: 4, Name: "auth", Path: `\services\api`},
+	}
+
+	root := azuredevops.NewFolderTree(folders, definitions)
+
+	if len(root.Definitions) != 1 || root.Definitions[0].Name != "docs" {
+		t.Fatalf("expected docs at the root; got %v", root.Definitions)
+	}
+
+	var paths []string
+	root.Walk(func(node *azuredevops.FolderNode, depth int) {
+		paths = append(paths, fmt.Sprintf("%d %s", depth, node.Path))
+	})
+
+	expected := []string{`0 \`, `1 \Archive`, `1 \services`, `2 \services\api`, `2 \services\web`}
+	if fmt.Sprint(paths) != fmt.Sprint(expected) {
+		t.Fatalf("expected folders %v; got %v", expected, paths)
+	}
+
+	api := root.Find(`services/API`)
+	if api == nil || api.Folder == nil || api.Name != "api" {
+		t.Fatalf("expected to find the api folder; got %+v", api)
+	}
+
+	if len(api.Definitions) != 2 || api.Definitions[0].Name != "auth" || api.Definitions[1].Name != "gateway" {
+		t.Fatalf("expected auth and gateway sorted by name; got %v", api.Definitions)
+	}
+
+	if web := root.Find(`\services\web`); web == nil || web.Folder != nil {
+		t.Fatalf("expected a web folder implied by its definition; got %+v", web)
+	}
+
+	if root.Find(`\missing`) != nil {
+		t.Fatalf("expected no node for a missing folder")
+	}
+}
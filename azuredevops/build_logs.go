@@ -0,0 +1,162 @@
+package azuredevops
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BuildLogsResponse is the wrapper around the main response for the List of
+// build logs
+type BuildLogsResponse struct {
+	Count int        `json:"count"`
+	Logs  []BuildLog `json:"value"`
+}
+
+// BuildLog describes one log of a build. Each step of a build writes its
+// own log
+type BuildLog struct {
+	ID            int    `json:"id"`
+	Type          string `json:"type"`
+	URL           string `json:"url"`
+	LineCount     int    `json:"lineCount"`
+	CreatedOn     Time   `json:"createdOn"`
+	LastChangedOn Time   `json:"lastChangedOn"`
+}
+
+// defaultFollowInterval is how often FollowLog polls unless told otherwise
+const defaultFollowInterval = 5 * time.Second
+
+// ListLogs returns the logs of a build
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/builds/get%20build%20logs
+func (s *BuildsService) ListLogs(ctx context.Context, buildID int) ([]BuildLog, error) {
+	URL := fmt.Sprintf("_apis/build/builds/%d/logs?api-version=%s", buildID, s.client.APIVersion(BuildLogsAPI))
+
+	request, err := s.client.NewRequestWithContext(ctx, "GET", URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	var response BuildLogsResponse
+	_, err = s.client.Execute(request, &response)
+
+	return response.Logs, err
+}
+
+// GetLog returns the text of a build log between startLine and endLine,
+// which count from 1. A zero startLine reads from the beginning and a zero
+// endLine reads to the end. The caller must close the returned reader
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/builds/get%20build%20log
+func (s *BuildsService) GetLog(ctx context.Context, buildID, logID, startLine, endLine int) (io.ReadCloser, error) {
+	query := url.Values{}
+	query.Set("api-version", s.client.APIVersion(BuildLogsAPI))
+	if startLine > 0 {
+		query.Set("startLine", strconv.Itoa(startLine))
+	}
+	if endLine > 0 {
+		query.Set("endLine", strconv.Itoa(endLine))
+	}
+	URL := fmt.Sprintf("_apis/build/builds/%d/logs/%d?%s", buildID, logID, query.Encode())
+
+	request, err := s.client.NewRequestWithContext(ctx, "GET", URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "text/plain")
+
+	response, err := s.client.executeStream(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Body, nil
+}
+
+// FollowLogOptions describes how FollowLog polls a build
+type FollowLogOptions struct {
+	// PollInterval is the time between polls, 5 seconds if zero
+	PollInterval time.Duration
+	// StartLine is the first line to write, counting from 1. Zero starts
+	// from the beginning
+	StartLine int
+}
+
+// FollowLog writes a build log to w as it grows, like tail -f, polling for
+// lines after the last one written. It returns once the build has completed
+// and the remaining lines have been written, or when ctx is done. A log that
+// doesn't exist yet, because its step hasn't started, is waited for
+func (s *BuildsService) FollowLog(ctx context.Context, buildID, logID int, w io.Writer, opts *FollowLogOptions) error {
+	interval := defaultFollowInterval
+	next := 1
+	if opts != nil {
+		if opts.PollInterval > 0 {
+			interval = opts.PollInterval
+		}
+		if opts.StartLine > 1 {
+			next = opts.StartLine
+		}
+	}
+
+	for {
+		// Check the build before reading the log, so lines written between
+		// the read and the build completing are picked up by the last read
+		build, err := s.Get(ctx, buildID)
+		if err != nil {
+			return err
+		}
+		completed := build.Status == BuildStatusCompleted
+
+		lines, err := s.copyLog(ctx, buildID, logID, next, w, completed)
+		next += lines
+		if err != nil && (completed || !IsNotFound(err)) {
+			return err
+		}
+
+		if completed {
+			return nil
+		}
+
+		if err := sleep(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+// copyLog writes the lines of a log from startLine onwards to w, returning
+// how many were written. The last line of a running build may still be
+// being written, so unless final is set a line without a line break is held
+// back until a later poll
+func (s *BuildsService) copyLog(ctx context.Context, buildID, logID, startLine int, w io.Writer, final bool) (int, error) {
+	body, err := s.GetLog(ctx, buildID, logID, startLine, 0)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+
+	lines := 0
+	reader := bufio.NewReader(body)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return lines, err
+		}
+		complete := strings.HasSuffix(line, "\n")
+		if line == "" || (!complete && !final) {
+			return lines, nil
+		}
+
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return lines, err
+		}
+		lines++
+
+		if !complete {
+			return lines, nil
+		}
+	}
+}
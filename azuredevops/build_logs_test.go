@@ -0,0 +1,268 @@
+package azuredevops_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/benmatselby/go-azuredevops/azuredevops"
+)
+
+const (
+	buildLogsURL = "/AZURE_DEVOPS_Project/_apis/build/builds/42/logs"
+	// https://docs.microsoft.com/en-us/rest/api/azure/devops/build/builds/get%20build%20logs
+	buildLogsResponse = `{
+		"count": 2,
+		"value": [
+			{
+				"lineCount": 4,
+				"createdOn": "2019-01-16T00:25:16.7Z",
+				"lastChangedOn": "2019-01-16T00:25:17.083Z",
+				"id": 1,
+				"type": "Container",
+				"url": "https://dev.azure.com/fabrikam/_apis/build/builds/42/logs/1"
+			},
+			{
+				"lineCount": 130,
+				"createdOn": "2019-01-16T00:25:17.537Z",
+				"lastChangedOn": "2019-01-16T00:25:17.713Z",
+				"id": 2,
+				"type": "Container",
+				"url": "https://dev.azure.com/fabrikam/_apis/build/builds/42/logs/2"
+			}
+		]
+	}`
+)
+
+func TestBuildsService_ListLogs(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(buildLogsURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, buildLogsResponse)
+	})
+
+	logs, err := c.Builds.ListLogs(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(logs) != 2 {
+		t.Fatalf("expected 2 logs; got %d", len(logs))
+	}
+
+	if logs[1].ID != 2 || logs[1].LineCount != 130 || logs[1].CreatedOn.IsZero() {
+		t.Fatalf("expected log 2 with 130 lines; got %v", logs[1])
+	}
+}
+
+func TestBuildsService_GetLog(t *testing.T) {
+	tt := []struct {
+		name      string
+		startLine int
+		endLine   int
+		query     string
+	}{
+		{name: "whole log", query: "api-version=4.1"},
+		{name: "line range", startLine: 10, endLine: 20, query: "api-version=4.1&endLine=20&startLine=10"},
+		{name: "from a line", startLine: 10, query: "api-version=4.1&startLine=10"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c, mux, _, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc(buildLogsURL+"/2", func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "GET")
+				if r.URL.RawQuery != tc.query {
+					t.Errorf("expected query %s; got %s", tc.query, r.URL.RawQuery)
+				}
+				if accept := r.Header.Get("Accept"); accept != "text/plain" {
+					t.Errorf("expected to accept text/plain; got %s", accept)
+				}
+				fmt.Fprint(w, "line one\r\nline two")
+			})
+
+			body, err := c.Builds.GetLog(context.Background(), 42, 2, tc.startLine, tc.endLine)
+			if err != nil {
+				t.Fatalf("returned error: %v", err)
+			}
+			defer body.Close()
+
+			text, err := io.ReadAll(body)
+			if err != nil {
+				t.Fatalf("returned error: %v", err)
+			}
+
+			if string(text) != "line one\r\nline two" {
+				t.Fatalf("expected the log text; got %q", text)
+			}
+		})
+	}
+}
+
+func TestBuildsService_GetLogNotFound(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(buildLogsURL+"/9", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	_, err := c.Builds.GetLog(context.Background(), 42, 9, 0, 0)
+	if !azuredevops.IsNotFound(err) {
+		t.Fatalf("expected a not found error; got %v", err)
+	}
+}
+
+func TestBuildsService_GetLogAPIVersionFallback(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+	c.APIVersionFallback = true
+	c.APIVersions = map[azuredevops.APIResource]string{azuredevops.BuildLogsAPI: "7.0"}
+
+	var versions []string
+	mux.HandleFunc(buildLogsURL+"/2", func(w http.ResponseWriter, r *http.Request) {
+		version := r.URL.Query().Get("api-version")
+		versions = append(versions, version)
+		if version == "7.0" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"message": "The requested REST API version of 7.0 is out of range for this server. The latest REST API version this server supports is 5.1.", "typeKey": "VssVersionOutOfRangeException"}`)
+			return
+		}
+		fmt.Fprint(w, "line one")
+	})
+
+	body, err := c.Builds.GetLog(context.Background(), 42, 2, 0, 0)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	defer body.Close()
+
+	data, _ := io.ReadAll(body)
+	if string(data) != "line one" {
+		t.Fatalf("expected the log from the fallback version; got %q", data)
+	}
+
+	if fmt.Sprint(versions) != "[7.0 5.1]" {
+		t.Fatalf("expected versions [7.0 5.1]; got %v", versions)
+	}
+}
+
+func TestBuildsService_FollowLog(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	// The log doesn't exist on the first poll, has two lines on the second
+	// and a third once the build has completed
+	var mu sync.Mutex
+	polls := 0
+	log := []string{}
+
+	mux.HandleFunc(buildListURL+"/42", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		polls++
+		switch polls {
+		case 2:
+			log = []string{"one", "two"}
+		case 3:
+			log = append(log, "three")
+			fmt.Fprint(w, `{"id": 42, "status": "completed"}`)
+			return
+		}
+		fmt.Fprint(w, `{"id": 42, "status": "inProgress"}`)
+	})
+
+	mux.HandleFunc(buildLogsURL+"/2", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if len(log) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		start, _ := strconv.Atoi(r.URL.Query().Get("startLine"))
+		fmt.Fprint(w, strings.Join(log[start-1:], "\r\n"))
+	})
+
+	var out strings.Builder
+	opts := &azuredevops.FollowLogOptions{PollInterval: time.Millisecond}
+	if err := c.Builds.FollowLog(context.Background(), 42, 2, &out, opts); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if out.String() != "one\ntwo\nthree\n" {
+		t.Fatalf("expected every line once; got %q", out.String())
+	}
+}
+
+func TestBuildsService_FollowLogPartialLine(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	// Each poll of the running build catches a line halfway through being
+	// written
+	var mu sync.Mutex
+	polls := 0
+	logs := []string{"one\r\ntw", "one\r\ntwo\r\nthr", "one\r\ntwo\r\nthree"}
+
+	mux.HandleFunc(buildListURL+"/42", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		polls++
+		if polls == len(logs) {
+			fmt.Fprint(w, `{"id": 42, "status": "completed"}`)
+			return
+		}
+		fmt.Fprint(w, `{"id": 42, "status": "inProgress"}`)
+	})
+
+	mux.HandleFunc(buildLogsURL+"/2", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		lines := strings.Split(logs[polls-1], "\r\n")
+		start, _ := strconv.Atoi(r.URL.Query().Get("startLine"))
+		fmt.Fprint(w, strings.Join(lines[start-1:], "\r\n"))
+	})
+
+	var out strings.Builder
+	opts := &azuredevops.FollowLogOptions{PollInterval: time.Millisecond}
+	if err := c.Builds.FollowLog(context.Background(), 42, 2, &out, opts); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if out.String() != "one\ntwo\nthree\n" {
+		t.Fatalf("expected every line whole and once; got %q", out.String())
+	}
+}
+
+func TestBuildsService_FollowLogCancelled(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(buildListURL+"/42", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 42, "status": "inProgress"}`)
+	})
+	mux.HandleFunc(buildLogsURL+"/2", func(w http.ResponseWriter, r *http.Request) {})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	opts := &azuredevops.FollowLogOptions{PollInterval: time.Millisecond}
+	err := c.Builds.FollowLog(ctx, 42, 2, io.Discard, opts)
+	if err != context.DeadlineExceeded {
+		t.Fatalf("expected the context deadline error; got %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// JSONPatchOperation is a single operation of a JSON patch document, see
+// https://tools.ietf.org/html/rfc6902
+type JSONPatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	From  string      `json:"from,omitempty"`
+	Value interface{} `json:"value"`
+}
+
+// propertyPath escapes a property name as a JSON pointer
+func propertyPath(name string) string {
+	return "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
+
+// SetProperty returns the operation that sets a property to value
+func SetProperty(name string, value interface{}) JSONPatchOperation {
+	return JSONPatchOperation{Op: "add", Path: propertyPath(name), Value: value}
+}
+
+// RemoveProperty returns the operation that removes a property
+func RemoveProperty(name string) JSONPatchOperation {
+	return JSONPatchOperation{Op: "remove", Path: propertyPath(name)}
+}
+
+// PropertyValue is a property as the API sends it, with its .NET type
+type PropertyValue struct {
+	Type  string      `json:"$type"`
+	Value interface{} `json:"$value"`
+}
+
+// PropertiesResponse is the wrapper around the main response for properties
+type PropertiesResponse struct {
+	Count      int                      `json:"count"`
+	Properties map[string]PropertyValue `json:"value"`
+}
+
+// values returns the properties without their types
+func (r PropertiesResponse) values() map[string]interface{} {
+	values := make(map[string]interface{}, len(r.Properties))
+	for name, property := range r.Properties {
+		values[name] = property.Value
+	}
+	return values
+}
+
+// GetProperties returns the properties of a build, or only those named in
+// filter if any are given
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/properties/get%20build%20properties
+func (s *BuildsService) GetProperties(ctx context.Context, buildID int, filter ...string) (map[string]interface{}, error) {
+	URL := fmt.Sprintf("_apis/build/builds/%d/properties?api-version=%s", buildID, s.client.APIVersion(BuildPropertiesAPI))
+	if len(filter) > 0 {
+		URL += "&filter=" + url.QueryEscape(strings.Join(filter, ","))
+	}
+
+	request, err := s.client.NewRequestWithContext(ctx, "GET", URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	var response PropertiesResponse
+	_, err = s.client.Execute(request, &response)
+
+	return response.values(), err
+}
+
+// UpdateProperties applies a JSON patch to the properties of a build and
+// returns them as they now are, e.g.
+//
+//	c.Builds.UpdateProperties(ctx, id, SetProperty("approvedBy", "release-bot"))
+//
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/properties/update%20build%20properties
+func (s *BuildsService) UpdateProperties(ctx context.Context, buildID int, operations ...JSONPatchOperation) (map[string]interface{}, error) {
+	URL := fmt.Sprintf("_apis/build/builds/%d/properties?api-version=%s", buildID, s.client.APIVersion(BuildPropertiesAPI))
+
+	if operations == nil {
+		operations = []JSONPatchOperation{}
+	}
+	request, err := s.client.NewRequestWithContext(ctx, "PATCH", URL, operations)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json-patch+json")
+
+	var response PropertiesResponse
+	_, err = s.client.Execute(request, &response)
+
+	return response.values(), err
+}
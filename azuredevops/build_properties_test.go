@@ -0,0 +1,81 @@
+package azuredevops_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/benmatselby/go-azuredevops/azuredevops"
+)
+
+const (
+	buildPropertiesURL = "/AZURE_DEVOPS_Project/_apis/build/builds/42/properties"
+	// https://docs.microsoft.com/en-us/rest/api/azure/devops/build/properties/get%20build%20properties
+	buildPropertiesResponse = `{
+		"count": 2,
+		"value": {
+			"approvedBy": {"$type": "System.String", "$value": "release-bot"},
+			"attempts": {"$type": "System.Int32", "$value": 3}
+		}
+	}`
+)
+
+func TestBuildsService_GetProperties(t *testing.T) {
+	tt := []struct {
+		name   string
+		filter []string
+		URL    string
+	}{
+		{name: "all properties", URL: buildPropertiesURL + "?api-version=4.1-preview.1"},
+		{name: "filtered properties", filter: []string{"approvedBy", "attempts"}, URL: buildPropertiesURL + "?api-version=4.1-preview.1&filter=approvedBy%2Cattempts"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c, mux, _, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc(buildPropertiesURL, func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "GET")
+				testURL(t, r, tc.URL)
+				fmt.Fprint(w, buildPropertiesResponse)
+			})
+
+			properties, err := c.Builds.GetProperties(context.Background(), 42, tc.filter...)
+			if err != nil {
+				t.Fatalf("returned error: %v", err)
+			}
+
+			if properties["approvedBy"] != "release-bot" || properties["attempts"] != float64(3) {
+				t.Fatalf("expected the property values; got %v", properties)
+			}
+		})
+	}
+}
+
+func TestBuildsService_UpdateProperties(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(buildPropertiesURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		if contentType := r.Header.Get("Content-Type"); contentType != "application/json-patch+json" {
+			t.Errorf("expected a json patch content type; got %s", contentType)
+		}
+		testBody(t, r, `[{"op":"add","path":"/approved~1by","value":false},{"op":"remove","path":"/stale~0name","value":null}]`+"\n")
+		fmt.Fprint(w, `{"count": 1, "value": {"approved/by": {"$type": "System.Boolean", "$value": false}}}`)
+	})
+
+	properties, err := c.Builds.UpdateProperties(context.Background(), 42,
+		azuredevops.SetProperty("approved/by", false),
+		azuredevops.RemoveProperty("stale~name"),
+	)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if value, ok := properties["approved/by"]; !ok || value != false {
+		t.Fatalf("expected the updated property; got %v", properties)
+	}
+}
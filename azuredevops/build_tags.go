@@ -0,0 +1,67 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// TagsResponse is the wrapper around the main response for a List of tags
+type TagsResponse struct {
+	Count int      `json:"count"`
+	Tags  []string `json:"value"`
+}
+
+// AddTags adds tags to a build and returns every tag the build now has
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/tags/add%20build%20tags
+func (s *BuildsService) AddTags(ctx context.Context, buildID int, tags ...string) ([]string, error) {
+	URL := fmt.Sprintf("_apis/build/builds/%d/tags?api-version=%s", buildID, s.client.APIVersion(BuildTagsAPI))
+
+	if tags == nil {
+		tags = []string{}
+	}
+	request, err := s.client.NewRequestWithContext(ctx, "POST", URL, tags)
+	if err != nil {
+		return nil, err
+	}
+	var response TagsResponse
+	_, err = s.client.Execute(request, &response)
+
+	return response.Tags, err
+}
+
+// RemoveTag removes a tag from a build and returns the tags the build has left
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/tags/delete%20build%20tag
+func (s *BuildsService) RemoveTag(ctx context.Context, buildID int, tag string) ([]string, error) {
+	URL := fmt.Sprintf(
+		"_apis/build/builds/%d/tags/%s?api-version=%s",
+		buildID,
+		url.PathEscape(tag),
+		s.client.APIVersion(BuildTagsAPI),
+	)
+
+	request, err := s.client.NewRequestWithContext(ctx, "DELETE", URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	var response TagsResponse
+	_, err = s.client.Execute(request, &response)
+
+	return response.Tags, err
+}
+
+// ListTags returns every tag used by a build in the project. Use
+// BuildsListOptions.Tags to find the builds with a tag
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/tags/get%20tags
+func (s *BuildsService) ListTags(ctx context.Context) ([]string, error) {
+	URL := fmt.Sprintf("_apis/build/tags?api-version=%s", s.client.APIVersion(BuildTagsAPI))
+
+	request, err := s.client.NewRequestWithContext(ctx, "GET", URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	var response TagsResponse
+	_, err = s.client.Execute(request, &response)
+
+	return response.Tags, err
+}
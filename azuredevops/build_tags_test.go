@@ -0,0 +1,72 @@
+package azuredevops_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const buildTagsURL = "/AZURE_DEVOPS_Project/_apis/build/builds/42/tags"
+
+func TestBuildsService_AddTags(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(buildTagsURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `["approved-for-prod","release"]`+"\n")
+		fmt.Fprint(w, `{"count": 3, "value": ["approved-for-prod", "nightly", "release"]}`)
+	})
+
+	tags, err := c.Builds.AddTags(context.Background(), 42, "approved-for-prod", "release")
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(tags, []string{"approved-for-prod", "nightly", "release"}) {
+		t.Fatalf("expected every tag on the build; got %v", tags)
+	}
+}
+
+func TestBuildsService_RemoveTag(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(buildTagsURL+"/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		if r.URL.EscapedPath() != buildTagsURL+"/needs%20review" {
+			t.Errorf("expected the tag to be escaped; got %s", r.URL.EscapedPath())
+		}
+		fmt.Fprint(w, `{"count": 1, "value": ["nightly"]}`)
+	})
+
+	tags, err := c.Builds.RemoveTag(context.Background(), 42, "needs review")
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(tags, []string{"nightly"}) {
+		t.Fatalf("expected the remaining tag; got %v", tags)
+	}
+}
+
+func TestBuildsService_ListTags(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/AZURE_DEVOPS_Project/_apis/build/tags", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"count": 2, "value": ["approved-for-prod", "nightly"]}`)
+	})
+
+	tags, err := c.Builds.ListTags(context.Background())
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(tags) != 2 {
+		t.Fatalf("expected 2 tags; got %d", len(tags))
+	}
+}
@@ -0,0 +1,250 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Timeline is the record of the stages, phases, jobs and tasks of a build
+type Timeline struct {
+	ID            string           `json:"id"`
+	ChangeID      int              `json:"changeId"`
+	LastChangedOn Time             `json:"lastChangedOn"`
+	URL           string           `json:"url"`
+	Records       []TimelineRecord `json:"records"`
+	// Roots are the records without a parent, usually the stages, as set by
+	// Tree
+	Roots []*TimelineRecord `json:"-"`
+}
+
+// TimelineRecord is one stage, phase, job or task of a build. Records are
+// flat in the API response, Timeline.Tree links them to their parents
+type TimelineRecord struct {
+	ID               string              `json:"id"`
+	ParentID         string              `json:"parentId"`
+	Type             string              `json:"type"`
+	Name             string              `json:"name"`
+	Identifier       string              `json:"identifier"`
+	Order            int                 `json:"order"`
+	Attempt          int                 `json:"attempt"`
+	StartTime        Time                `json:"startTime"`
+	FinishTime       Time                `json:"finishTime"`
+	CurrentOperation string              `json:"currentOperation"`
+	PercentComplete  int                 `json:"percentComplete"`
+	State            TimelineRecordState `json:"state"`
+	Result           TaskResult          `json:"result"`
+	ResultCode       string              `json:"resultCode"`
+	ChangeID         int                 `json:"changeId"`
+	LastModified     Time                `json:"lastModified"`
+	WorkerName       string              `json:"workerName"`
+	ErrorCount       int                 `json:"errorCount"`
+	WarningCount     int                 `json:"warningCount"`
+	Issues           []TimelineIssue     `json:"issues"`
+	URL              string              `json:"url"`
+	Log              *struct {
+		ID   int    `json:"id"`
+		Type string `json:"type"`
+		URL  string `json:"url"`
+	} `json:"log,omitempty"`
+	Task *struct {
+		ID      string `json:"id"`
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	} `json:"task,omitempty"`
+
+	// Parent and Children are set by Timeline.Tree
+	Parent   *TimelineRecord   `json:"-"`
+	Children []*TimelineRecord `json:"-"`
+}
+
+// TimelineIssue is an error or warning raised by a timeline record
+type TimelineIssue struct {
+	Type     string            `json:"type"`
+	Category string            `json:"category"`
+	Message  string            `json:"message"`
+	Data     map[string]string `json:"data"`
+}
+
+// TimelineRecordState is enum type for the state of a timeline record
+type TimelineRecordState string
+
+const (
+	// TimelineRecordStatePending is a record that has not started
+	TimelineRecordStatePending TimelineRecordState = "pending"
+	// TimelineRecordStateInProgress is a record that is running
+	TimelineRecordStateInProgress TimelineRecordState = "inProgress"
+	// TimelineRecordStateCompleted is a record that has finished
+	TimelineRecordStateCompleted TimelineRecordState = "completed"
+)
+
+var timelineRecordStates = []TimelineRecordState{
+	TimelineRecordStatePending, TimelineRecordStateInProgress, TimelineRecordStateCompleted,
+}
+
+// String returns the state as the API spells it
+func (s TimelineRecordState) String() string {
+	return string(s)
+}
+
+// Valid reports whether s is a known timeline record state
+func (s TimelineRecordState) Valid() bool {
+	return validEnum(s, timelineRecordStates)
+}
+
+// UnmarshalJSON decodes a timeline record state, ignoring case
+func (s *TimelineRecordState) UnmarshalJSON(data []byte) (err error) {
+	*s, err = unmarshalEnum(data, timelineRecordStates)
+	return err
+}
+
+// TaskResult is enum type for the result of a timeline record
+type TaskResult string
+
+const (
+	// TaskResultSucceeded is a record that succeeded
+	TaskResultSucceeded TaskResult = "succeeded"
+	// TaskResultSucceededWithIssues is a record that succeeded with warnings
+	TaskResultSucceededWithIssues TaskResult = "succeededWithIssues"
+	// TaskResultFailed is a record that failed
+	TaskResultFailed TaskResult = "failed"
+	// TaskResultCanceled is a record that was cancelled
+	TaskResultCanceled TaskResult = "canceled"
+	// TaskResultSkipped is a record that was skipped
+	TaskResultSkipped TaskResult = "skipped"
+	// TaskResultAbandoned is a record that was abandoned
+	TaskResultAbandoned TaskResult = "abandoned"
+)
+
+var taskResults = []TaskResult{
+	TaskResultSucceeded, TaskResultSucceededWithIssues, TaskResultFailed,
+	TaskResultCanceled, TaskResultSkipped, TaskResultAbandoned,
+}
+
+// String returns the result as the API spells it
+func (r TaskResult) String() string {
+	return string(r)
+}
+
+// Valid reports whether r is a known task result
+func (r TaskResult) Valid() bool {
+	return validEnum(r, taskResults)
+}
+
+// UnmarshalJSON decodes a task result, ignoring case
+func (r *TaskResult) UnmarshalJSON(data []byte) (err error) {
+	*r, err = unmarshalEnum(data, taskResults)
+	return err
+}
+
+// GetTimeline returns the timeline of a build with its records assembled
+// into a tree under Roots, see Timeline.Tree
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/timeline/get
+func (s *BuildsService) GetTimeline(ctx context.Context, buildID int) (*Timeline, error) {
+	URL := fmt.Sprintf("_apis/build/builds/%d/timeline?api-version=%s", buildID, s.client.APIVersion(BuildTimelineAPI))
+
+	request, err := s.client.NewRequestWithContext(ctx, "GET", URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	var response Timeline
+	_, err = s.client.Execute(request, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	response.Tree()
+	return &response, nil
+}
+
+// Tree links each record to its parent and children and returns the records
+// without a parent, usually the stages, also setting them as Roots. Siblings
+// are sorted by their order. Records whose parent isn't in the timeline are
+// treated as roots
+func (t *Timeline) Tree() []*TimelineRecord {
+	byID := make(map[string]*TimelineRecord, len(t.Records))
+	for i := range t.Records {
+		record := &t.Records[i]
+		record.Parent = nil
+		record.Children = nil
+		byID[record.ID] = record
+	}
+
+	var roots []*TimelineRecord
+	for i := range t.Records {
+		record := &t.Records[i]
+		parent, ok := byID[record.ParentID]
+		if !ok || parent == record {
+			roots = append(roots, record)
+			continue
+		}
+		record.Parent = parent
+		parent.Children = append(parent.Children, record)
+	}
+
+	sortRecords(roots)
+	for i := range t.Records {
+		sortRecords(t.Records[i].Children)
+	}
+
+	t.Roots = roots
+	return roots
+}
+
+// Failures returns the failed tasks of the timeline, in the order they ran
+func (t *Timeline) Failures() []*TimelineRecord {
+	var failures []*TimelineRecord
+	for i := range t.Records {
+		record := &t.Records[i]
+		if record.Type == "Task" && record.Result == TaskResultFailed {
+			failures = append(failures, record)
+		}
+	}
+
+	sort.SliceStable(failures, func(i, j int) bool {
+		return failures[i].StartTime.Before(failures[j].StartTime.Time)
+	})
+	return failures
+}
+
+func sortRecords(records []*TimelineRecord) {
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Order < records[j].Order
+	})
+}
+
+// Duration returns how long the record ran for. A record still running is
+// measured up to now, and one that hasn't started is zero
+func (r *TimelineRecord) Duration() time.Duration {
+	if r.StartTime.IsZero() {
+		return 0
+	}
+	if r.FinishTime.IsZero() {
+		return time.Since(r.StartTime.Time)
+	}
+	return r.FinishTime.Sub(r.StartTime.Time)
+}
+
+// Path returns the names of the record's ancestors and the record itself,
+// e.g. [Build, Linux, Run tests]. Call Timeline.Tree first
+func (r *TimelineRecord) Path() []string {
+	var path []string
+	seen := map[*TimelineRecord]bool{}
+	for record := r; record != nil && !seen[record]; record = record.Parent {
+		seen[record] = true
+		path = append([]string{record.Name}, path...)
+	}
+	return path
+}
+
+// Errors returns the messages of the record's error issues
+func (r *TimelineRecord) Errors() []string {
+	var messages []string
+	for _, issue := range r.Issues {
+		if issue.Type == "error" {
+			messages = append(messages, issue.Message)
+		}
+	}
+	return messages
+}
@@ -0,0 +1,176 @@
+package azuredevops_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/benmatselby/go-azuredevops/azuredevops"
+)
+
+const (
+	buildTimelineURL = "/AZURE_DEVOPS_Project/_apis/build/builds/42/timeline"
+	// https://docs.microsoft.com/en-us/rest/api/azure/devops/build/timeline/get
+	buildTimelineResponse = `{
+		"records": [
+			{
+				"id": "task-2",
+				"parentId": "job-1",
+				"type": "Task",
+				"name": "Run tests",
+				"order": 2,
+				"startTime": "2019-01-16T00:26:00Z",
+				"finishTime": "2019-01-16T00:27:30Z",
+				"state": "completed",
+				"result": "failed",
+				"errorCount": 1,
+				"warningCount": 0,
+				"issues": [
+					{"type": "error", "category": "General", "message": "Process completed with exit code 1.", "data": {"type": "error"}},
+					{"type": "warning", "category": "General", "message": "Retrying flaky test"}
+				],
+				"log": {"id": 7, "type": "Container", "url": "https://dev.azure.com/fabrikam/_apis/build/builds/42/logs/7"},
+				"task": {"id": "d9bafed4", "name": "CmdLine", "version": "2.1.0"}
+			},
+			{
+				"id": "stage-1",
+				"parentId": null,
+				"type": "Stage",
+				"name": "Build",
+				"order": 1,
+				"startTime": "2019-01-16T00:25:00Z",
+				"finishTime": "2019-01-16T00:27:31Z",
+				"state": "completed",
+				"result": "failed"
+			},
+			{
+				"id": "job-1",
+				"parentId": "stage-1",
+				"type": "Job",
+				"name": "Linux",
+				"order": 1,
+				"startTime": "2019-01-16T00:25:10Z",
+				"finishTime": "2019-01-16T00:27:31Z",
+				"state": "completed",
+				"result": "failed",
+				"workerName": "Hosted Agent"
+			},
+			{
+				"id": "task-1",
+				"parentId": "job-1",
+				"type": "Task",
+				"name": "Checkout",
+				"order": 1,
+				"startTime": "2019-01-16T00:25:10Z",
+				"finishTime": "2019-01-16T00:26:00Z",
+				"state": "completed",
+				"result": "succeeded"
+			},
+			{
+				"id": "stage-2",
+				"parentId": null,
+				"type": "Stage",
+				"name": "Deploy",
+				"order": 2,
+				"startTime": null,
+				"finishTime": null,
+				"state": "pending",
+				"result": null
+			}
+		],
+		"lastChangedOn": "2019-01-16T00:27:31Z",
+		"id": "8a5b4a1c",
+		"changeId": 12,
+		"url": "https://dev.azure.com/fabrikam/_apis/build/builds/42/timeline/8a5b4a1c"
+	}`
+)
+
+func TestBuildsService_GetTimeline(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(buildTimelineURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, buildTimelineResponse)
+	})
+
+	timeline, err := c.Builds.GetTimeline(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(timeline.Records) != 5 || timeline.ChangeID != 12 {
+		t.Fatalf("expected 5 records at change 12; got %d at %d", len(timeline.Records), timeline.ChangeID)
+	}
+
+	roots := timeline.Roots
+	if len(roots) != 2 || roots[0].Name != "Build" || roots[1].Name != "Deploy" {
+		t.Fatalf("expected the Build and Deploy stages as roots; got %v", roots)
+	}
+
+	job := roots[0].Children[0]
+	if job.Name != "Linux" || len(job.Children) != 2 {
+		t.Fatalf("expected the Linux job with 2 tasks; got %s with %d", job.Name, len(job.Children))
+	}
+
+	if job.Children[0].Name != "Checkout" || job.Children[1].Name != "Run tests" {
+		t.Fatalf("expected the tasks in order; got %s, %s", job.Children[0].Name, job.Children[1].Name)
+	}
+
+	if roots[1].State != azuredevops.TimelineRecordStatePending || roots[1].Duration() != 0 {
+		t.Fatalf("expected the Deploy stage to be pending with no duration; got %s %v", roots[1].State, roots[1].Duration())
+	}
+}
+
+func TestTimeline_Failures(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(buildTimelineURL, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, buildTimelineResponse)
+	})
+
+	timeline, err := c.Builds.GetTimeline(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	failures := timeline.Failures()
+	if len(failures) != 1 {
+		t.Fatalf("expected 1 failed task; got %d", len(failures))
+	}
+
+	failed := failures[0]
+	if path := failed.Path(); !reflect.DeepEqual(path, []string{"Build", "Linux", "Run tests"}) {
+		t.Fatalf("expected the path to the failed task; got %v", path)
+	}
+
+	if failed.Duration() != 90*time.Second {
+		t.Fatalf("expected the task to have run for 90s; got %v", failed.Duration())
+	}
+
+	if errs := failed.Errors(); len(errs) != 1 || errs[0] != "Process completed with exit code 1." {
+		t.Fatalf("expected the error message; got %v", errs)
+	}
+
+	if failed.Log == nil || failed.Log.ID != 7 || failed.Result != azuredevops.TaskResultFailed {
+		t.Fatalf("expected the failed task's log; got %v", failed.Log)
+	}
+}
+
+func TestTimeline_TreeOrphans(t *testing.T) {
+	timeline := &azuredevops.Timeline{
+		Records: []azuredevops.TimelineRecord{
+			{ID: "a", ParentID: "missing", Name: "orphan"},
+			{ID: "b", ParentID: "b", Name: "self"},
+		},
+	}
+
+	roots := timeline.Tree()
+	if len(roots) != 2 {
+		t.Fatalf("expected orphaned records to become roots; got %d", len(roots))
+	}
+}
@@ -0,0 +1,93 @@
+package azuredevops
+
+import (
+	"context"
+	"time"
+)
+
+// defaultWaitInterval is how often WaitForCompletion polls unless told
+// otherwise
+const defaultWaitInterval = 10 * time.Second
+
+// BuildProgress reports a change in a build seen by WaitForCompletion
+type BuildProgress struct {
+	Build *Build
+	// PreviousStatus is the status before this change, empty the first time
+	PreviousStatus BuildStatus
+	// QueuePosition is the build's place in the queue while it waits for an
+	// agent, zero once it has started
+	QueuePosition int
+}
+
+// WaitOptions describes how WaitForCompletion polls a build
+type WaitOptions struct {
+	// PollInterval is the time between polls, 10 seconds if zero
+	PollInterval time.Duration
+	// Progress, if set, is called with the build when first seen and again
+	// whenever its status or queue position changes
+	Progress func(BuildProgress)
+}
+
+// WaitForCompletion polls a build until it has completed and returns it, so
+// its Result can be checked. It returns early with the context's error if
+// ctx is done, so use a deadline to bound the wait
+func (s *BuildsService) WaitForCompletion(ctx context.Context, buildID int, opts *WaitOptions) (*Build, error) {
+	interval := defaultWaitInterval
+	var progress func(BuildProgress)
+	if opts != nil {
+		if opts.PollInterval > 0 {
+			interval = opts.PollInterval
+		}
+		progress = opts.Progress
+	}
+
+	var previous *Build
+	for {
+		build, err := s.Get(ctx, buildID)
+		if err != nil {
+			return nil, err
+		}
+
+		if progress != nil && buildChanged(previous, build) {
+			update := BuildProgress{Build: build, QueuePosition: queuePosition(build)}
+			if previous != nil {
+				update.PreviousStatus = previous.Status
+			}
+			progress(update)
+		}
+
+		if build.Status == BuildStatusCompleted {
+			return build, nil
+		}
+		previous = build
+
+		if err := sleep(ctx, interval); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// QueueAndWait queues build and waits for it to complete, returning the
+// completed build. build is updated with the queued build, so its ID is
+// known even if the wait fails
+func (s *BuildsService) QueueAndWait(ctx context.Context, build *Build, queueOpts *QueueBuildOptions, waitOpts *WaitOptions) (*Build, error) {
+	if err := s.Queue(ctx, build, queueOpts); err != nil {
+		return nil, err
+	}
+	return s.WaitForCompletion(ctx, build.ID, waitOpts)
+}
+
+// buildChanged reports whether current differs from previous in a way worth
+// reporting as progress
+func buildChanged(previous, current *Build) bool {
+	return previous == nil ||
+		previous.Status != current.Status ||
+		queuePosition(previous) != queuePosition(current)
+}
+
+func queuePosition(build *Build) int {
+	if build.QueuePosition == nil {
+		return 0
+	}
+	return *build.QueuePosition
+}
@@ -0,0 +1,124 @@
+package azuredevops_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/benmatselby/go-azuredevops/azuredevops"
+)
+
+// buildSequence serves each response in turn for build 42, repeating the
+// last one
+func buildSequence(t *testing.T, mux *http.ServeMux, responses ...string) {
+	var mu sync.Mutex
+	polls := 0
+	mux.HandleFunc(buildListURL+"/42", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		mu.Lock()
+		defer mu.Unlock()
+
+		response := responses[len(responses)-1]
+		if polls < len(responses) {
+			response = responses[polls]
+		}
+		polls++
+		fmt.Fprint(w, response)
+	})
+}
+
+func TestBuildsService_WaitForCompletion(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	buildSequence(t, mux,
+		`{"id": 42, "status": "notStarted", "queuePosition": 2}`,
+		`{"id": 42, "status": "notStarted", "queuePosition": 2}`,
+		`{"id": 42, "status": "notStarted", "queuePosition": 1}`,
+		`{"id": 42, "status": "inProgress"}`,
+		`{"id": 42, "status": "inProgress"}`,
+		`{"id": 42, "status": "completed", "result": "failed"}`,
+	)
+
+	var updates []azuredevops.BuildProgress
+	opts := &azuredevops.WaitOptions{
+		PollInterval: time.Millisecond,
+		Progress: func(p azuredevops.BuildProgress) {
+			updates = append(updates, p)
+		},
+	}
+
+	build, err := c.Builds.WaitForCompletion(context.Background(), 42, opts)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if build.Result != azuredevops.BuildResultFailed {
+		t.Fatalf("expected the failed result; got %s", build.Result)
+	}
+
+	tt := []struct {
+		previous azuredevops.BuildStatus
+		status   azuredevops.BuildStatus
+		position int
+	}{
+		{previous: "", status: azuredevops.BuildStatusNotStarted, position: 2},
+		{previous: azuredevops.BuildStatusNotStarted, status: azuredevops.BuildStatusNotStarted, position: 1},
+		{previous: azuredevops.BuildStatusNotStarted, status: azuredevops.BuildStatusInProgress, position: 0},
+		{previous: azuredevops.BuildStatusInProgress, status: azuredevops.BuildStatusCompleted, position: 0},
+	}
+
+	if len(updates) != len(tt) {
+		t.Fatalf("expected %d progress updates; got %d", len(tt), len(updates))
+	}
+
+	for i, tc := range tt {
+		got := updates[i]
+		if got.PreviousStatus != tc.previous || got.Build.Status != tc.status || got.QueuePosition != tc.position {
+			t.Fatalf("expected update %d to be %s -> %s at %d; got %s -> %s at %d", i, tc.previous, tc.status, tc.position, got.PreviousStatus, got.Build.Status, got.QueuePosition)
+		}
+	}
+}
+
+func TestBuildsService_WaitForCompletionDeadline(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	buildSequence(t, mux, `{"id": 42, "status": "inProgress"}`)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := c.Builds.WaitForCompletion(ctx, 42, &azuredevops.WaitOptions{PollInterval: time.Millisecond})
+	if err != context.DeadlineExceeded {
+		t.Fatalf("expected the context deadline error; got %v", err)
+	}
+}
+
+func TestBuildsService_QueueAndWait(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(queueBuildURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"id": 42, "status": "notStarted"}`)
+	})
+	buildSequence(t, mux, `{"id": 42, "status": "inProgress"}`, `{"id": 42, "status": "completed", "result": "succeeded"}`)
+
+	queued := &azuredevops.Build{Definition: azuredevops.BuildDefinition{ID: 1}}
+	build, err := c.Builds.QueueAndWait(context.Background(), queued, nil, &azuredevops.WaitOptions{PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if queued.ID != 42 {
+		t.Fatalf("expected the queued build to be updated with its ID; got %d", queued.ID)
+	}
+
+	if build.Result != azuredevops.BuildResultSucceeded {
+		t.Fatalf("expected the build to succeed; got %s", build.Result)
+	}
+}
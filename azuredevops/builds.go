@@ -1,6 +1,7 @@
 package azuredevops
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -27,7 +28,7 @@ type Build struct {
 	LastChangedBy *IdentityRef     `json:"lastChangedBy,omitempty"`
 	DeletedBy     *IdentityRef     `json:"deletedBy,omitempty"`
 	BuildNumber   string           `json:"buildNumber,omitempty"`
-	FinishTime    string           `json:"finishTime,omitempty"`
+	FinishTime    *Time            `json:"finishTime,omitempty"`
 	Branch        string           `json:"sourceBranch"`
 	Repository    Repository       `json:"repository"`
 	Demands       []struct {
@@ -55,11 +56,11 @@ type Build struct {
 	Plans               []buildOrchestrationPlanSchema `json:"plans,omitempty"`
 	BuildNumberRevision int                            `json:"buildNumberRevision,omitempty"`
 	Deleted             *bool                          `json:"deleted,omitempty"`
-	DeletedDate         string                         `json:"deletedDate,omitempty"`
+	DeletedDate         *Time                          `json:"deletedDate,omitempty"`
 	DeletedReason       string                         `json:"deletedReason,omitempty"`
 	ID                  int                            `json:"id,omitempty"`
 	KeepForever         bool                           `json:"keepForever,omitempty"`
-	ChangedDate         string                         `json:"lastChangedDate,omitempty"`
+	ChangedDate         *Time                          `json:"lastChangedDate,omitempty"`
 	Params              string                         `json:"parameters,omitempty"`
 	Quality             string                         `json:"quality,omitempty"`
 	Queue               struct {
@@ -74,12 +75,12 @@ type Build struct {
 	} `json:"queue"`
 	QueueOptions      map[string]string `json:"queue_options"`
 	QueuePosition     *int              `json:"queuePosition,omitempty"`
-	QueueTime         string            `json:"queueTime,omitempty"`
+	QueueTime         *Time             `json:"queueTime,omitempty"`
 	RetainedByRelease *bool             `json:"retainedByRelease,omitempty"`
 	Version           string            `json:"sourceVersion,omitempty"`
-	StartTime         string            `json:"startTime,omitempty"`
-	Status            string            `json:"status,omitempty"`
-	Result            string            `json:"result,omitempty"`
+	StartTime         *Time             `json:"startTime,omitempty"`
+	Status            BuildStatus       `json:"status,omitempty"`
+	Result            BuildResult       `json:"result,omitempty"`
 	ValidationResults []struct {
 		Message string `json:"message"`
 		Result  string `json:"result"`
@@ -113,6 +114,140 @@ const (
 	StartTimeDescending BuildListOrder = "startTimeDescending"
 )
 
+// BuildStatus is enum type for the status of a build
+type BuildStatus string
+
+const (
+	// BuildStatusNone is a build with no status
+	BuildStatusNone BuildStatus = "none"
+	// BuildStatusInProgress is a build that is running
+	BuildStatusInProgress BuildStatus = "inProgress"
+	// BuildStatusCompleted is a build that has finished
+	BuildStatusCompleted BuildStatus = "completed"
+	// BuildStatusCancelling is a build being cancelled
+	BuildStatusCancelling BuildStatus = "cancelling"
+	// BuildStatusPostponed is a build that has been postponed
+	BuildStatusPostponed BuildStatus = "postponed"
+	// BuildStatusNotStarted is a build waiting in the queue
+	BuildStatusNotStarted BuildStatus = "notStarted"
+	// BuildStatusAll matches every status when filtering
+	BuildStatusAll BuildStatus = "all"
+)
+
+var buildStatuses = []BuildStatus{
+	BuildStatusNone, BuildStatusInProgress, BuildStatusCompleted, BuildStatusCancelling,
+	BuildStatusPostponed, BuildStatusNotStarted, BuildStatusAll,
+}
+
+// String returns the status as the API spells it
+func (s BuildStatus) String() string {
+	return string(s)
+}
+
+// Valid reports whether s is a known build status
+func (s BuildStatus) Valid() bool {
+	return validEnum(s, buildStatuses)
+}
+
+// UnmarshalJSON decodes a build status, ignoring case
+func (s *BuildStatus) UnmarshalJSON(data []byte) (err error) {
+	*s, err = unmarshalEnum(data, buildStatuses)
+	return err
+}
+
+// BuildResult is enum type for the result of a completed build
+type BuildResult string
+
+const (
+	// BuildResultNone is a build with no result yet
+	BuildResultNone BuildResult = "none"
+	// BuildResultSucceeded is a build that succeeded
+	BuildResultSucceeded BuildResult = "succeeded"
+	// BuildResultPartiallySucceeded is a build that succeeded with failed steps
+	BuildResultPartiallySucceeded BuildResult = "partiallySucceeded"
+	// BuildResultFailed is a build that failed
+	BuildResultFailed BuildResult = "failed"
+	// BuildResultCanceled is a build that was cancelled
+	BuildResultCanceled BuildResult = "canceled"
+)
+
+var buildResults = []BuildResult{
+	BuildResultNone, BuildResultSucceeded, BuildResultPartiallySucceeded, BuildResultFailed, BuildResultCanceled,
+}
+
+// String returns the result as the API spells it
+func (r BuildResult) String() string {
+	return string(r)
+}
+
+// Valid reports whether r is a known build result
+func (r BuildResult) Valid() bool {
+	return validEnum(r, buildResults)
+}
+
+// UnmarshalJSON decodes a build result, ignoring case
+func (r *BuildResult) UnmarshalJSON(data []byte) (err error) {
+	*r, err = unmarshalEnum(data, buildResults)
+	return err
+}
+
+// BuildReason is enum type for why a build was queued
+type BuildReason string
+
+const (
+	// BuildReasonNone is a build with no reason
+	BuildReasonNone BuildReason = "none"
+	// BuildReasonManual is a build queued by a user
+	BuildReasonManual BuildReason = "manual"
+	// BuildReasonIndividualCI is a build triggered by a single check-in
+	BuildReasonIndividualCI BuildReason = "individualCI"
+	// BuildReasonBatchedCI is a build triggered by batched check-ins
+	BuildReasonBatchedCI BuildReason = "batchedCI"
+	// BuildReasonSchedule is a build triggered by a schedule
+	BuildReasonSchedule BuildReason = "schedule"
+	// BuildReasonScheduleForced is a scheduled build run without changes
+	BuildReasonScheduleForced BuildReason = "scheduleForced"
+	// BuildReasonUserCreated is a build created by a user
+	BuildReasonUserCreated BuildReason = "userCreated"
+	// BuildReasonValidateShelveset is a build validating a shelveset
+	BuildReasonValidateShelveset BuildReason = "validateShelveset"
+	// BuildReasonCheckInShelveset is a gated check-in build
+	BuildReasonCheckInShelveset BuildReason = "checkInShelveset"
+	// BuildReasonPullRequest is a build triggered by a pull request
+	BuildReasonPullRequest BuildReason = "pullRequest"
+	// BuildReasonBuildCompletion is a build triggered by another build
+	BuildReasonBuildCompletion BuildReason = "buildCompletion"
+	// BuildReasonResourceTrigger is a build triggered by a pipeline resource
+	BuildReasonResourceTrigger BuildReason = "resourceTrigger"
+	// BuildReasonTriggered matches every triggered reason when filtering
+	BuildReasonTriggered BuildReason = "triggered"
+	// BuildReasonAll matches every reason when filtering
+	BuildReasonAll BuildReason = "all"
+)
+
+var buildReasons = []BuildReason{
+	BuildReasonNone, BuildReasonManual, BuildReasonIndividualCI, BuildReasonBatchedCI,
+	BuildReasonSchedule, BuildReasonScheduleForced, BuildReasonUserCreated,
+	BuildReasonValidateShelveset, BuildReasonCheckInShelveset, BuildReasonPullRequest,
+	BuildReasonBuildCompletion, BuildReasonResourceTrigger, BuildReasonTriggered, BuildReasonAll,
+}
+
+// String returns the reason as the API spells it
+func (r BuildReason) String() string {
+	return string(r)
+}
+
+// Valid reports whether r is a known build reason
+func (r BuildReason) Valid() bool {
+	return validEnum(r, buildReasons)
+}
+
+// UnmarshalJSON decodes a build reason, ignoring case
+func (r *BuildReason) UnmarshalJSON(data []byte) (err error) {
+	*r, err = unmarshalEnum(data, buildReasons)
+	return err
+}
+
 // BuildsListOptions describes what the request to the API should look like
 type BuildsListOptions struct {
 	Definitions      string         `url:"definitions,omitempty"`
@@ -126,9 +261,9 @@ type BuildsListOptions struct {
 	Token            string         `url:"continuationToken,omitempty"`
 	Props            string         `url:"properties,omitempty"`
 	Tags             string         `url:"tagFilters,omitempty"`
-	Result           string         `url:"resultFilter,omitempty"`
-	Status           string         `url:"statusFilter,omitempty"`
-	Reason           string         `url:"reasonFilter,omitempty"`
+	Result           []BuildResult  `url:"resultFilter,omitempty,comma"`
+	Status           []BuildStatus  `url:"statusFilter,omitempty,comma"`
+	Reason           []BuildReason  `url:"reasonFilter,omitempty,comma"`
 	UserID           string         `url:"requestedFor,omitempty"`
 	MaxTime          string         `url:"maxTime,omitempty"`
 	MinTime          string         `url:"minTime,omitempty"`
@@ -137,20 +272,63 @@ type BuildsListOptions struct {
 	RepoType         string         `url:"repositoryType,omitempty"`
 }
 
-// List returns list of the builds
+// validate checks the enum filters before they are sent, as the API ignores
+// values it doesn't recognise and returns every build instead
+func (o *BuildsListOptions) validate() error {
+	if o == nil {
+		return nil
+	}
+	if err := validateEnums("build status", o.Status, buildStatuses); err != nil {
+		return err
+	}
+	if err := validateEnums("build result", o.Result, buildResults); err != nil {
+		return err
+	}
+	return validateEnums("build reason", o.Reason, buildReasons)
+}
+
+// List returns list of the builds along with the continuation token for the
+// next page, which is empty when there are no more builds
 // utilising https://docs.microsoft.com/en-gb/rest/api/vsts/build/builds/list
-func (s *BuildsService) List(opts *BuildsListOptions) ([]Build, error) {
-	URL := fmt.Sprintf("_apis/build/builds?api-version=4.1")
+func (s *BuildsService) List(ctx context.Context, opts *BuildsListOptions) ([]Build, string, error) {
+	if err := opts.validate(); err != nil {
+		return nil, "", err
+	}
+
+	URL := fmt.Sprintf("_apis/build/builds?api-version=%s", s.client.APIVersion(BuildsAPI))
 	URL, err := addOptions(URL, opts)
 
-	request, err := s.client.NewRequest("GET", URL, nil)
+	request, err := s.client.NewRequestWithContext(ctx, "GET", URL, nil)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	var response BuildsListResponse
-	_, err = s.client.Execute(request, &response)
+	resp, err := s.client.Execute(request, &response)
 
-	return response.Builds, err
+	return response.Builds, continuationToken(resp), err
+}
+
+// Pager returns a Pager that walks every page of builds matching opts
+func (s *BuildsService) Pager(opts *BuildsListOptions) *Pager[Build] {
+	return NewPager(s.listPage(opts))
+}
+
+// ListAll returns every build matching opts, following continuation tokens
+func (s *BuildsService) ListAll(ctx context.Context, opts *BuildsListOptions) ([]Build, error) {
+	return ListAll(ctx, s.listPage(opts))
+}
+
+func (s *BuildsService) listPage(opts *BuildsListOptions) PageFunc[Build] {
+	return func(ctx context.Context, token string) ([]Build, string, error) {
+		pageOpts := BuildsListOptions{}
+		if opts != nil {
+			pageOpts = *opts
+		}
+		if token != "" {
+			pageOpts.Token = token
+		}
+		return s.List(ctx, &pageOpts)
+	}
 }
 
 // QueueBuildOptions describes what the request to the API should look like
@@ -161,15 +339,15 @@ type QueueBuildOptions struct {
 
 // Queue inserts new build creation to queue
 // utilising https://docs.microsoft.com/en-us/rest/api/vsts/build/builds/queue?view=vsts-rest-4.1
-func (s *BuildsService) Queue(build *Build, opts *QueueBuildOptions) error {
-	URL := "_apis/build/builds?api-version=4.1"
+func (s *BuildsService) Queue(ctx context.Context, build *Build, opts *QueueBuildOptions) error {
+	URL := fmt.Sprintf("_apis/build/builds?api-version=%s", s.client.APIVersion(BuildsAPI))
 	URL, err := addOptions(URL, opts)
 
 	if err != nil {
 		return err
 	}
 
-	request, err := s.client.NewRequest("POST", URL, build)
+	request, err := s.client.NewRequestWithContext(ctx, "POST", URL, build)
 
 	if err != nil {
 		return err
@@ -179,3 +357,101 @@ func (s *BuildsService) Queue(build *Build, opts *QueueBuildOptions) error {
 
 	return err
 }
+
+// Get returns a single build
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/builds/get
+func (s *BuildsService) Get(ctx context.Context, buildID int) (*Build, error) {
+	URL := fmt.Sprintf("_apis/build/builds/%d?api-version=%s", buildID, s.client.APIVersion(BuildsAPI))
+
+	request, err := s.client.NewRequestWithContext(ctx, "GET", URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	var response Build
+	_, err = s.client.Execute(request, &response)
+
+	return &response, err
+}
+
+// BuildUpdate describes the fields of a build that can be changed. Nil and
+// empty fields are left as they are
+type BuildUpdate struct {
+	// ID is only needed when updating builds in bulk with UpdateBuilds
+	ID                int         `json:"id,omitempty"`
+	Status            BuildStatus `json:"status,omitempty"`
+	KeepForever       *bool       `json:"keepForever,omitempty"`
+	RetainedByRelease *bool       `json:"retainedByRelease,omitempty"`
+}
+
+// Update changes a build, for example to retain it with KeepForever
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/builds/update%20build
+func (s *BuildsService) Update(ctx context.Context, buildID int, update *BuildUpdate) (*Build, error) {
+	URL := fmt.Sprintf("_apis/build/builds/%d?api-version=%s", buildID, s.client.APIVersion(BuildsAPI))
+
+	request, err := s.client.NewRequestWithContext(ctx, "PATCH", URL, update)
+	if err != nil {
+		return nil, err
+	}
+	var response Build
+	_, err = s.client.Execute(request, &response)
+
+	return &response, err
+}
+
+// UpdateBuilds changes several builds in one request. Each update must have
+// its ID set
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/builds/update%20builds
+func (s *BuildsService) UpdateBuilds(ctx context.Context, updates []BuildUpdate) ([]Build, error) {
+	for _, update := range updates {
+		if update.ID == 0 {
+			return nil, fmt.Errorf("azuredevops: every build update needs an ID")
+		}
+	}
+
+	URL := fmt.Sprintf("_apis/build/builds?api-version=%s", s.client.APIVersion(BuildsAPI))
+
+	request, err := s.client.NewRequestWithContext(ctx, "PATCH", URL, updates)
+	if err != nil {
+		return nil, err
+	}
+	var response BuildsListResponse
+	_, err = s.client.Execute(request, &response)
+
+	return response.Builds, err
+}
+
+// Cancel asks Azure DevOps to stop a running or queued build. The returned
+// build has the cancelling status until the agent stops
+func (s *BuildsService) Cancel(ctx context.Context, buildID int) (*Build, error) {
+	return s.Update(ctx, buildID, &BuildUpdate{Status: BuildStatusCancelling})
+}
+
+// Retry re-runs the failed jobs of a completed build
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/builds/update%20build
+func (s *BuildsService) Retry(ctx context.Context, buildID int) (*Build, error) {
+	URL := fmt.Sprintf("_apis/build/builds/%d?retry=true&api-version=%s", buildID, s.client.APIVersion(BuildsAPI))
+
+	request, err := s.client.NewRequestWithContext(ctx, "PATCH", URL, struct{}{})
+	if err != nil {
+		return nil, err
+	}
+	var response Build
+	_, err = s.client.Execute(request, &response)
+
+	return &response, err
+}
+
+// Delete deletes a build. Builds retained with KeepForever or by a release
+// must be released first
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/builds/delete
+func (s *BuildsService) Delete(ctx context.Context, buildID int) error {
+	URL := fmt.Sprintf("_apis/build/builds/%d?api-version=%s", buildID, s.client.APIVersion(BuildsAPI))
+
+	request, err := s.client.NewRequestWithContext(ctx, "DELETE", URL, nil)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.Execute(request, nil)
+
+	return err
+}
@@ -1,6 +1,7 @@
 package azuredevops_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -39,11 +40,11 @@ func TestBuildsService_List(t *testing.T) {
 		response       string
 		count          int
 		index          int
-		status         string
-		result         string
+		status         azuredevops.BuildStatus
+		result         azuredevops.BuildResult
 		definitionName string
 	}{
-		{name: "return two builds", URL: buildListURL, response: buildListResponse, count: 2, index: 0, status: "completed", result: "succeeded", definitionName: "build-one"},
+		{name: "return two builds", URL: buildListURL, response: buildListResponse, count: 2, index: 0, status: azuredevops.BuildStatusCompleted, result: azuredevops.BuildResultSucceeded, definitionName: "build-one"},
 		{name: "can handle no builds returned", URL: buildListURL, response: "{}", count: 0, index: -1},
 	}
 
@@ -59,7 +60,7 @@ func TestBuildsService_List(t *testing.T) {
 			})
 
 			options := &azuredevops.BuildsListOptions{}
-			builds, err := c.Builds.List(options)
+			builds, _, err := c.Builds.List(context.Background(), options)
 			if err != nil {
 				t.Fatalf("returned error: %v", err)
 			}
@@ -116,7 +117,7 @@ func TestBuildsService_Queue(t *testing.T) {
 
 		options := &azuredevops.QueueBuildOptions{}
 
-		err := c.Builds.Queue(requestBuild, options)
+		err := c.Builds.Queue(context.Background(), requestBuild, options)
 
 		if err != nil {
 			t.Fatalf("returned error: %v", err)
@@ -135,3 +136,170 @@ func TestBuildsService_Queue(t *testing.T) {
 		}
 	})
 }
+
+func TestBuildsService_ListAll(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(buildListURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch r.URL.Query().Get("continuationToken") {
+		case "":
+			w.Header().Set("x-ms-continuationtoken", "page-2")
+			fmt.Fprint(w, `{"value": [{"id": 1}, {"id": 2}]}`)
+		case "page-2":
+			fmt.Fprint(w, `{"value": [{"id": 3}]}`)
+		default:
+			t.Fatalf("unexpected continuation token %s", r.URL.Query().Get("continuationToken"))
+		}
+	})
+
+	builds, token, err := c.Builds.List(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if token != "page-2" {
+		t.Fatalf("expected continuation token page-2, got %s", token)
+	}
+
+	if len(builds) != 2 {
+		t.Fatalf("expected length of first page to be 2; got %d", len(builds))
+	}
+
+	builds, err = c.Builds.ListAll(context.Background(), &azuredevops.BuildsListOptions{Definitions: "12"})
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(builds) != 3 {
+		t.Fatalf("expected length of builds to be 3; got %d", len(builds))
+	}
+
+	for index, build := range builds {
+		if build.ID != index+1 {
+			t.Fatalf("expected build %d to have id %d; got %d", index, index+1, build.ID)
+		}
+	}
+}
+
+func TestBuildsService_Get(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(buildListURL+"/42", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id": 42, "buildNumber": "20240101.1", "status": "inProgress"}`)
+	})
+
+	build, err := c.Builds.Get(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if build.ID != 42 || build.BuildNumber != "20240101.1" || build.Status != azuredevops.BuildStatusInProgress {
+		t.Fatalf("expected build 42 in progress; got %d %s %s", build.ID, build.BuildNumber, build.Status)
+	}
+}
+
+func TestBuildsService_Update(t *testing.T) {
+	tt := []struct {
+		name   string
+		update func(c *azuredevops.Client) (*azuredevops.Build, error)
+		query  string
+		body   string
+	}{
+		{
+			name: "retains a build",
+			update: func(c *azuredevops.Client) (*azuredevops.Build, error) {
+				return c.Builds.Update(context.Background(), 42, &azuredevops.BuildUpdate{KeepForever: azuredevops.Bool(true)})
+			},
+			query: "api-version=4.1",
+			body:  `{"keepForever":true}` + "\n",
+		},
+		{
+			name: "cancels a build",
+			update: func(c *azuredevops.Client) (*azuredevops.Build, error) {
+				return c.Builds.Cancel(context.Background(), 42)
+			},
+			query: "api-version=4.1",
+			body:  `{"status":"cancelling"}` + "\n",
+		},
+		{
+			name: "retries a build",
+			update: func(c *azuredevops.Client) (*azuredevops.Build, error) {
+				return c.Builds.Retry(context.Background(), 42)
+			},
+			query: "retry=true&api-version=4.1",
+			body:  "{}\n",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c, mux, _, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc(buildListURL+"/42", func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "PATCH")
+				if r.URL.RawQuery != tc.query {
+					t.Errorf("expected query %s; got %s", tc.query, r.URL.RawQuery)
+				}
+				testBody(t, r, tc.body)
+				fmt.Fprint(w, `{"id": 42}`)
+			})
+
+			build, err := tc.update(c)
+			if err != nil {
+				t.Fatalf("returned error: %v", err)
+			}
+
+			if build.ID != 42 {
+				t.Fatalf("expected build 42; got %d", build.ID)
+			}
+		})
+	}
+}
+
+func TestBuildsService_UpdateBuilds(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(buildListURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `[{"id":1,"keepForever":false},{"id":2,"keepForever":false}]`+"\n")
+		fmt.Fprint(w, `{"count": 2, "value": [{"id": 1}, {"id": 2}]}`)
+	})
+
+	updates := []azuredevops.BuildUpdate{
+		{ID: 1, KeepForever: azuredevops.Bool(false)},
+		{ID: 2, KeepForever: azuredevops.Bool(false)},
+	}
+	builds, err := c.Builds.UpdateBuilds(context.Background(), updates)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(builds) != 2 {
+		t.Fatalf("expected 2 builds; got %d", len(builds))
+	}
+
+	_, err = c.Builds.UpdateBuilds(context.Background(), []azuredevops.BuildUpdate{{Status: azuredevops.BuildStatusCancelling}})
+	if err == nil {
+		t.Fatalf("expected an error for an update without an ID, did not get one")
+	}
+}
+
+func TestBuildsService_Delete(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(buildListURL+"/42", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := c.Builds.Delete(context.Background(), 42); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+}
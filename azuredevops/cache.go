@@ -0,0 +1,331 @@
+package azuredevops
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// CachedResponse is a response kept by a CacheStore
+type CachedResponse struct {
+	StatusCode   int         `json:"status"`
+	Header       http.Header `json:"header"`
+	Body         []byte      `json:"body"`
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"lastModified,omitempty"`
+	StoredAt     time.Time   `json:"storedAt"`
+}
+
+// CacheStore stores cached responses by key. Implementations must be safe
+// for concurrent use
+type CacheStore interface {
+	Get(key string) (*CachedResponse, bool)
+	Set(key string, response *CachedResponse)
+	Delete(key string)
+}
+
+// cacheHeader is set on responses served by the Cache, to "1" when served
+// within the TTL without contacting the API and to "revalidated" when the
+// API answered 304 Not Modified
+const cacheHeader = "X-From-Cache"
+
+// defaultCacheEntries is the size of the MemoryCache used when a Cache has
+// no Store
+const defaultCacheEntries = 1000
+
+// rateLimitHeaders are the headers ParseRateLimit reads. They describe the
+// moment a response was sent, so they are not kept in the cache
+var rateLimitHeaders = []string{
+	"X-RateLimit-Resource", "X-RateLimit-Limit", "X-RateLimit-Remaining",
+	"X-RateLimit-Reset", "X-RateLimit-Delay", "Retry-After",
+}
+
+// Cache makes conditional requests for GETs, serving the stored body when
+// Azure DevOps answers 304 Not Modified. Within its TTL a stored response is
+// served without contacting the API at all
+type Cache struct {
+	// Store keeps the responses. If nil, a MemoryCache of 1000 entries is
+	// used
+	Store CacheStore
+	// DefaultTTL is how long a response is served without revalidating it.
+	// Zero means always revalidate
+	DefaultTTL time.Duration
+	// TTLs overrides DefaultTTL by operation, e.g. "work/boards", or by area,
+	// e.g. "work". See OperationFor
+	TTLs map[string]time.Duration
+}
+
+// WithCache adds cache to the Client's middleware
+func WithCache(cache *Cache) ClientOption {
+	return WithMiddleware(cache.Middleware())
+}
+
+// ttl returns the TTL for op
+func (c *Cache) ttl(op Operation) time.Duration {
+	if ttl, ok := c.TTLs[op.String()]; ok {
+		return ttl
+	}
+	if ttl, ok := c.TTLs[op.Area]; ok {
+		return ttl
+	}
+	return c.DefaultTTL
+}
+
+// cacheKey identifies request in the store. The credentials are part of the
+// key, hashed, so one identity is never served another's data
+func cacheKey(request *http.Request) string {
+	h := sha256.New()
+	io.WriteString(h, request.URL.String())
+	io.WriteString(h, "\x00")
+	io.WriteString(h, request.Header.Get("Authorization"))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Middleware returns the Middleware that implements the cache
+func (c *Cache) Middleware() Middleware {
+	if c.Store == nil {
+		c.Store = NewMemoryCache(defaultCacheEntries)
+	}
+
+	return func(next Doer) Doer {
+		return DoerFunc(func(request *http.Request) (*http.Response, error) {
+			if request.Method != http.MethodGet {
+				return next.Do(request)
+			}
+
+			key := cacheKey(request)
+			cached, ok := c.Store.Get(key)
+			if ok && time.Since(cached.StoredAt) < c.ttl(OperationFor(request)) {
+				return cached.response(request, "1"), nil
+			}
+
+			if ok {
+				request = request.Clone(request.Context())
+				if cached.ETag != "" {
+					request.Header.Set("If-None-Match", cached.ETag)
+				}
+				if cached.LastModified != "" {
+					request.Header.Set("If-Modified-Since", cached.LastModified)
+				}
+			}
+
+			response, err := next.Do(request)
+			if err != nil {
+				return response, err
+			}
+
+			if ok && response.StatusCode == http.StatusNotModified {
+				response.Body.Close()
+				cached.StoredAt = time.Now()
+				c.Store.Set(key, cached)
+
+				// The rate limit is the API's current one, not the stored one
+				served := cached.response(request, "revalidated")
+				for _, name := range rateLimitHeaders {
+					if values := response.Header.Values(name); len(values) > 0 {
+						served.Header[http.CanonicalHeaderKey(name)] = values
+					}
+				}
+				return served, nil
+			}
+
+			if response.StatusCode != http.StatusOK {
+				return response, nil
+			}
+
+			etag := response.Header.Get("ETag")
+			lastModified := response.Header.Get("Last-Modified")
+			if etag == "" && lastModified == "" && c.ttl(OperationFor(request)) == 0 {
+				// Nothing to revalidate with and nothing to serve from
+				return response, nil
+			}
+
+			body, err := io.ReadAll(response.Body)
+			response.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			response.Body = io.NopCloser(bytes.NewReader(body))
+
+			header := response.Header.Clone()
+			for _, name := range rateLimitHeaders {
+				header.Del(name)
+			}
+
+			c.Store.Set(key, &CachedResponse{
+				StatusCode:   response.StatusCode,
+				Header:       header,
+				Body:         body,
+				ETag:         etag,
+				LastModified: lastModified,
+				StoredAt:     time.Now(),
+			})
+
+			return response, nil
+		})
+	}
+}
+
+// response builds an http.Response for request from the cached copy, with
+// cacheHeader set to from
+func (c *CachedResponse) response(request *http.Request, from string) *http.Response {
+	header := c.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set(cacheHeader, from)
+
+	return &http.Response{
+		Status:        strconv.Itoa(c.StatusCode) + " " + http.StatusText(c.StatusCode),
+		StatusCode:    c.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(c.Body)),
+		ContentLength: int64(len(c.Body)),
+		Request:       request,
+	}
+}
+
+// MemoryCache is an in-memory CacheStore that evicts the least recently used
+// entry once it holds MaxEntries
+type MemoryCache struct {
+	maxEntries int
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type memoryCacheEntry struct {
+	key      string
+	response *CachedResponse
+}
+
+// NewMemoryCache returns a MemoryCache holding up to maxEntries responses,
+// or an unbounded one if maxEntries is zero
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    map[string]*list.Element{},
+	}
+}
+
+// Get returns the response stored for key
+func (m *MemoryCache) Get(key string) (*CachedResponse, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	element, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	m.order.MoveToFront(element)
+	copied := *element.Value.(*memoryCacheEntry).response
+	return &copied, true
+}
+
+// Set stores response for key
+func (m *MemoryCache) Set(key string, response *CachedResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if element, ok := m.entries[key]; ok {
+		element.Value.(*memoryCacheEntry).response = response
+		m.order.MoveToFront(element)
+		return
+	}
+
+	m.entries[key] = m.order.PushFront(&memoryCacheEntry{key: key, response: response})
+	if m.maxEntries > 0 && m.order.Len() > m.maxEntries {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryCacheEntry).key)
+	}
+}
+
+// Delete removes the response stored for key
+func (m *MemoryCache) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if element, ok := m.entries[key]; ok {
+		m.order.Remove(element)
+		delete(m.entries, key)
+	}
+}
+
+// Len returns the number of stored responses
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
+
+// DiskCache is a CacheStore that keeps each response as a JSON file in a
+// directory, so the cache survives restarts
+type DiskCache struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewDiskCache returns a DiskCache storing files in dir, creating it if needed
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+func (d *DiskCache) path(key string) string {
+	return filepath.Join(d.dir, key+".json")
+}
+
+// Get returns the response stored for key. Unreadable files are treated as
+// a miss
+func (d *DiskCache) Get(key string) (*CachedResponse, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	data, err := os.ReadFile(d.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var response CachedResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, false
+	}
+	return &response, true
+}
+
+// Set stores response for key. Failing to write is not fatal to a request,
+// so errors are ignored and the next request is simply a miss
+func (d *DiskCache) Set(key string, response *CachedResponse) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(d.path(key), data, 0o600)
+}
+
+// Delete removes the response stored for key
+func (d *DiskCache) Delete(key string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_ = os.Remove(d.path(key))
+}
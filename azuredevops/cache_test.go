@@ -0,0 +1,203 @@
+package azuredevops_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/benmatselby/go-azuredevops/azuredevops"
+)
+
+func TestCache_ETag(t *testing.T) {
+	tt := []struct {
+		name  string
+		store func(t *testing.T) azuredevops.CacheStore
+	}{
+		{name: "memory", store: func(t *testing.T) azuredevops.CacheStore { return azuredevops.NewMemoryCache(10) }},
+		{name: "disk", store: func(t *testing.T) azuredevops.CacheStore {
+			d, err := azuredevops.NewDiskCache(t.TempDir())
+			if err != nil {
+				t.Fatalf("returned error: %v", err)
+			}
+			return d
+		}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c, mux, _, teardown := setup()
+			defer teardown()
+
+			cache := &azuredevops.Cache{Store: tc.store(t)}
+			c.Middleware = []azuredevops.Middleware{cache.Middleware()}
+
+			requests := 0
+			notModified := 0
+			mux.HandleFunc(boardListURL, func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if r.Header.Get("If-None-Match") == `"v1"` {
+					notModified++
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				w.Header().Set("ETag", `"v1"`)
+				fmt.Fprint(w, boardListResponse)
+			})
+
+			for i := 0; i < 3; i++ {
+				boards, err := c.Boards.List(context.Background(), "AZURE_DEVOPS_TEAM")
+				if err != nil {
+					t.Fatalf("returned error: %v", err)
+				}
+				if len(boards) != 2 {
+					t.Fatalf("expected 2 boards on request %d; got %d", i, len(boards))
+				}
+			}
+
+			if requests != 3 || notModified != 2 {
+				t.Fatalf("expected 3 requests with 2 revalidated; got %d and %d", requests, notModified)
+			}
+		})
+	}
+}
+
+func TestCache_TTL(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	cache := &azuredevops.Cache{
+		Store: azuredevops.NewMemoryCache(10),
+		TTLs:  map[string]time.Duration{"work/boards": time.Minute},
+	}
+	c.Middleware = []azuredevops.Middleware{cache.Middleware()}
+
+	requests := 0
+	mux.HandleFunc(boardListURL, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, boardListResponse)
+	})
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.Boards.List(context.Background(), "AZURE_DEVOPS_TEAM"); err != nil {
+			t.Fatalf("returned error: %v", err)
+		}
+	}
+
+	if requests != 1 {
+		t.Fatalf("expected 1 request within the ttl; got %d", requests)
+	}
+}
+
+func TestCache_NotSharedBetweenCredentials(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	cache := &azuredevops.Cache{Store: azuredevops.NewMemoryCache(10), DefaultTTL: time.Minute}
+	c.Middleware = []azuredevops.Middleware{cache.Middleware()}
+
+	requests := 0
+	mux.HandleFunc(boardListURL, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, boardListResponse)
+	})
+
+	for _, token := range []string{"one", "two"} {
+		c.AuthToken = token
+		if _, err := c.Boards.List(context.Background(), "AZURE_DEVOPS_TEAM"); err != nil {
+			t.Fatalf("returned error: %v", err)
+		}
+	}
+
+	if requests != 2 {
+		t.Fatalf("expected a request per token; got %d", requests)
+	}
+}
+
+func TestCache_DefaultStore(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	azuredevops.WithCache(&azuredevops.Cache{DefaultTTL: time.Minute})(c)
+
+	requests := 0
+	mux.HandleFunc(boardListURL, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, boardListResponse)
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.Boards.List(context.Background(), "AZURE_DEVOPS_TEAM"); err != nil {
+			t.Fatalf("returned error: %v", err)
+		}
+	}
+
+	if requests != 1 {
+		t.Fatalf("expected 1 request with the default store; got %d", requests)
+	}
+}
+
+func TestCache_RateLimit(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	cache := &azuredevops.Cache{
+		Store: azuredevops.NewMemoryCache(10),
+		TTLs:  map[string]time.Duration{"work/boards": time.Minute},
+	}
+	c.Middleware = []azuredevops.Middleware{cache.Middleware()}
+
+	remaining := map[string]string{"one": "10", "two": "5"}
+	mux.HandleFunc(boardListURL, func(w http.ResponseWriter, r *http.Request) {
+		_, token, _ := r.BasicAuth()
+		w.Header().Set("X-RateLimit-Remaining", remaining[token])
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.Header().Set("X-RateLimit-Remaining", "3")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fmt.Fprint(w, boardListResponse)
+	})
+
+	list := func(token string) {
+		c.AuthToken = token
+		if _, err := c.Boards.List(context.Background(), "AZURE_DEVOPS_TEAM"); err != nil {
+			t.Fatalf("returned error: %v", err)
+		}
+	}
+
+	list("one")
+	list("two")
+	list("one")
+	if rl := c.RateLimit(); rl.Remaining != 5 {
+		t.Fatalf("expected the rate limit of the last request sent; got %d", rl.Remaining)
+	}
+
+	cache.TTLs = nil
+	list("one")
+	if rl := c.RateLimit(); rl.Remaining != 3 {
+		t.Fatalf("expected the rate limit of the revalidation; got %d", rl.Remaining)
+	}
+}
+
+func TestMemoryCache_Evicts(t *testing.T) {
+	m := azuredevops.NewMemoryCache(2)
+	m.Set("a", &azuredevops.CachedResponse{})
+	m.Set("b", &azuredevops.CachedResponse{})
+	m.Get("a")
+	m.Set("c", &azuredevops.CachedResponse{})
+
+	if _, ok := m.Get("b"); ok {
+		t.Fatalf("expected the least recently used entry to be evicted")
+	}
+
+	if _, ok := m.Get("a"); !ok {
+		t.Fatalf("expected the recently used entry to be kept")
+	}
+
+	if m.Len() != 2 {
+		t.Fatalf("expected 2 entries; got %d", m.Len())
+	}
+}
@@ -1,6 +1,7 @@
 package azuredevops
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -19,8 +20,8 @@ type DeliveryPlansListResponse struct {
 
 // DeliveryPlanTimeLine describes the delivery plan get response
 type DeliveryPlanTimeLine struct {
-	StartDate string         `json:"startDate"`
-	EndDate   string         `json:"endDate"`
+	StartDate Time           `json:"startDate"`
+	EndDate   Time           `json:"endDate"`
 	ID        string         `json:"id"`
 	Revision  int            `json:"revision"`
 	Teams     []DeliveryTeam `json:"teams"`
@@ -31,7 +32,7 @@ type DeliveryPlan struct {
 	ID      string `json:"id"`
 	Name    string `json:"name"`
 	Type    string `json:"type"`
-	Created string `json:"createdDate"`
+	Created Time   `json:"createdDate"`
 	URL     string `json:"url"`
 }
 
@@ -62,11 +63,11 @@ type DeliveryPlansListOptions struct {
 }
 
 // List returns a list of delivery plans
-func (s *DeliveryPlansService) List(opts *DeliveryPlansListOptions) ([]DeliveryPlan, int, error) {
-	URL := fmt.Sprintf("_apis/work/plans?api-version=6.1-preview.1")
+func (s *DeliveryPlansService) List(ctx context.Context, opts *DeliveryPlansListOptions) ([]DeliveryPlan, int, error) {
+	URL := fmt.Sprintf("_apis/work/plans?api-version=%s", s.client.APIVersion(DeliveryPlansAPI))
 	URL, err := addOptions(URL, opts)
 
-	request, err := s.client.NewRequest("GET", URL, nil)
+	request, err := s.client.NewRequestWithContext(ctx, "GET", URL, nil)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -77,10 +78,11 @@ func (s *DeliveryPlansService) List(opts *DeliveryPlansListOptions) ([]DeliveryP
 }
 
 // GetTimeLine will fetch the details about a specific delivery plan
-func (s *DeliveryPlansService) GetTimeLine(ID string, startDate, endDate string) (*DeliveryPlanTimeLine, error) {
+func (s *DeliveryPlansService) GetTimeLine(ctx context.Context, ID string, startDate, endDate string) (*DeliveryPlanTimeLine, error) {
 	URL := fmt.Sprintf(
-		"_apis/work/plans/%s/deliverytimeline?api-version=5.0-preview.1",
+		"_apis/work/plans/%s/deliverytimeline?api-version=%s",
 		ID,
+		s.client.APIVersion(DeliveryTimelineAPI),
 	)
 
 	if startDate == "" {
@@ -91,7 +93,7 @@ func (s *DeliveryPlansService) GetTimeLine(ID string, startDate, endDate string)
 
 	URL = fmt.Sprintf(URL+"&startDate=%s&endDate=%s", startDate, endDate)
 
-	request, err := s.client.NewRequest("GET", URL, nil)
+	request, err := s.client.NewRequestWithContext(ctx, "GET", URL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package azuredevops_test
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"testing"
@@ -95,7 +96,7 @@ func TestDeliveryPlansService_List(t *testing.T) {
 			})
 
 			options := &azuredevops.DeliveryPlansListOptions{}
-			plans, count, err := c.DeliveryPlans.List(options)
+			plans, count, err := c.DeliveryPlans.List(context.Background(), options)
 			if err != nil {
 				t.Fatalf("returned error: %v", err)
 			}
@@ -133,7 +134,7 @@ func TestDeliveryPlansService_GetTimeLine(t *testing.T) {
 		fmt.Fprint(w, json)
 	})
 
-	timeline, err := c.DeliveryPlans.GetTimeLine(planID, "", "")
+	timeline, err := c.DeliveryPlans.GetTimeLine(context.Background(), planID, "", "")
 	if err != nil {
 		t.Fatalf("returned error: %v", err)
 	}
@@ -199,10 +200,41 @@ func TestDeliveryPlansService_GetTimeLineDates(t *testing.T) {
 				fmt.Fprint(w, json)
 			})
 
-			_, err := c.DeliveryPlans.GetTimeLine(planID, tc.startDate, tc.endDate)
+			_, err := c.DeliveryPlans.GetTimeLine(context.Background(), planID, tc.startDate, tc.endDate)
 			if err != nil {
 				t.Fatalf("returned error: %v", err)
 			}
 		})
 	}
 }
+
+func TestDeliveryPlansService_GetTimeLine_Cassette(t *testing.T) {
+	c := cassetteClient(t, "delivery_plan_timeline")
+
+	planID := cassetteEnv("AZURE_DEVOPS_PLAN_ID", "7154147c-43ca-44a9-9df0-2fa0a7f9d6b2")
+	timeline, err := c.DeliveryPlans.GetTimeLine(context.Background(), planID, "2018-05-04", "2018-07-06")
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if timeline.ID != planID {
+		t.Fatalf("expected delivery plan id %s, got %s", planID, timeline.ID)
+	}
+
+	if len(timeline.Teams) == 0 || len(timeline.Teams[0].Iterations) == 0 {
+		t.Fatalf("expected the timeline to have teams with iterations")
+	}
+
+	for _, team := range timeline.Teams {
+		for _, iteration := range team.Iterations {
+			for _, item := range iteration.WorkItems {
+				if _, ok := item[azuredevops.DeliveryPlanWorkItemIDKey].(float64); !ok {
+					t.Fatalf("expected work item id to be a number; got %T", item[azuredevops.DeliveryPlanWorkItemIDKey])
+				}
+				if _, ok := item[azuredevops.DeliveryPlanWorkItemNameKey].(string); !ok {
+					t.Fatalf("expected work item name to be a string; got %T", item[azuredevops.DeliveryPlanWorkItemNameKey])
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// enumValue returns the member of values equal to value ignoring case, so
+// "Completed" decodes to "completed". Values the package doesn't know yet are
+// kept as they are rather than rejected
+func enumValue[T ~string](value string, values []T) T {
+	for _, v := range values {
+		if strings.EqualFold(string(v), value) {
+			return v
+		}
+	}
+	return T(value)
+}
+
+// unmarshalEnum decodes a JSON string into one of values
+func unmarshalEnum[T ~string](data []byte, values []T) (T, error) {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return "", err
+	}
+	return enumValue(value, values), nil
+}
+
+// validEnum reports whether value is exactly one of values
+func validEnum[T ~string](value T, values []T) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// validateEnums returns an error naming the first of set that isn't one of
+// values
+func validateEnums[T ~string](kind string, set []T, values []T) error {
+	for _, value := range set {
+		if !validEnum(value, values) {
+			return fmt.Errorf("azuredevops: invalid %s %q", kind, value)
+		}
+	}
+	return nil
+}
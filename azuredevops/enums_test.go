@@ -0,0 +1,108 @@
+package azuredevops_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/benmatselby/go-azuredevops/azuredevops"
+)
+
+func TestEnums_UnmarshalJSON(t *testing.T) {
+	var build azuredevops.Build
+	if err := json.Unmarshal([]byte(`{"status": "InProgress", "result": "PARTIALLYSUCCEEDED"}`), &build); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if build.Status != azuredevops.BuildStatusInProgress {
+		t.Fatalf("expected status %s; got %s", azuredevops.BuildStatusInProgress, build.Status)
+	}
+
+	if build.Result != azuredevops.BuildResultPartiallySucceeded {
+		t.Fatalf("expected result %s; got %s", azuredevops.BuildResultPartiallySucceeded, build.Result)
+	}
+
+	var result azuredevops.TestResult
+	if err := json.Unmarshal([]byte(`{"outcome": "notExecuted"}`), &result); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if result.Outcome != azuredevops.TestOutcomeNotExecuted {
+		t.Fatalf("expected outcome %s; got %s", azuredevops.TestOutcomeNotExecuted, result.Outcome)
+	}
+
+	var pr azuredevops.PullRequest
+	if err := json.Unmarshal([]byte(`{"status": "superseded"}`), &pr); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if pr.Status != "superseded" || pr.Status.Valid() {
+		t.Fatalf("expected an unknown status to be kept but not valid; got %s", pr.Status)
+	}
+}
+
+func TestEnums_Valid(t *testing.T) {
+	tt := []struct {
+		name     string
+		valid    bool
+		expected bool
+	}{
+		{name: "build status", valid: azuredevops.BuildStatusNotStarted.Valid(), expected: true},
+		{name: "misspelt build status", valid: azuredevops.BuildStatus("complete").Valid(), expected: false},
+		{name: "build reason", valid: azuredevops.BuildReasonPullRequest.Valid(), expected: true},
+		{name: "wrong case build reason", valid: azuredevops.BuildReason("PullRequest").Valid(), expected: false},
+		{name: "pull request status", valid: azuredevops.PullRequestStatusAbandoned.Valid(), expected: true},
+		{name: "test run state", valid: azuredevops.TestRunStateNeedsInvestigation.Valid(), expected: true},
+		{name: "test outcome", valid: azuredevops.TestOutcome("Skipped").Valid(), expected: false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.valid != tc.expected {
+				t.Fatalf("expected valid to be %v; got %v", tc.expected, tc.valid)
+			}
+		})
+	}
+}
+
+func TestBuildsService_ListFilters(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(buildListURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testURL(t, r, buildListURL+"?api-version=4.1&reasonFilter=manual%2CpullRequest&resultFilter=failed%2Ccanceled&statusFilter=completed")
+		fmt.Fprint(w, "{}")
+	})
+
+	opts := &azuredevops.BuildsListOptions{
+		Status: []azuredevops.BuildStatus{azuredevops.BuildStatusCompleted},
+		Result: []azuredevops.BuildResult{azuredevops.BuildResultFailed, azuredevops.BuildResultCanceled},
+		Reason: []azuredevops.BuildReason{azuredevops.BuildReasonManual, azuredevops.BuildReasonPullRequest},
+	}
+	if _, _, err := c.Builds.List(context.Background(), opts); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+}
+
+func TestEnums_InvalidFilters(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("expected no request to be sent; got %s", r.URL)
+	})
+
+	ctx := context.Background()
+	_, _, err := c.Builds.List(ctx, &azuredevops.BuildsListOptions{Status: []azuredevops.BuildStatus{"complete"}})
+	if err == nil {
+		t.Fatalf("expected an error for an invalid build status, did not get one")
+	}
+
+	_, _, err = c.PullRequests.List(ctx, &azuredevops.PullRequestListOptions{State: "open"})
+	if err == nil {
+		t.Fatalf("expected an error for an invalid pull request status, did not get one")
+	}
+}
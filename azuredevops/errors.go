@@ -0,0 +1,108 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// activityIDHeader is the header Azure DevOps uses to correlate a request
+// with its server side logs
+const activityIDHeader = "ActivityId"
+
+// ErrorResponse is returned by Client.Execute when the API responds with a
+// non successful status code. It carries the decoded Azure DevOps error
+// payload where one was sent
+type ErrorResponse struct {
+	// Response is the HTTP response that caused this error, the originating
+	// request is available from Response.Request
+	Response *http.Response `json:"-"`
+	// StatusCode is the HTTP status code of the response
+	StatusCode int `json:"-"`
+	// ActivityID is the value of the ActivityId response header
+	ActivityID string `json:"-"`
+	// RateLimit holds any throttling headers sent with the response
+	RateLimit RateLimit `json:"-"`
+
+	Message   string `json:"message"`
+	TypeName  string `json:"typeName"`
+	TypeKey   string `json:"typeKey"`
+	ErrorCode int    `json:"errorCode"`
+	EventID   int    `json:"eventId"`
+}
+
+func (e *ErrorResponse) Error() string {
+	URL := ""
+	if e.Response != nil && e.Response.Request != nil {
+		URL = e.Response.Request.URL.String()
+	}
+
+	msg := fmt.Sprintf("Request to %s responded with status %d", URL, e.StatusCode)
+	if e.TypeKey != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.TypeKey)
+	}
+	if e.Message != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Message)
+	}
+	return msg
+}
+
+// newErrorResponse builds an ErrorResponse from response, decoding the
+// body if it looks like an Azure DevOps error payload. Any body that
+// cannot be decoded is ignored so the status code is never lost
+func newErrorResponse(response *http.Response) *ErrorResponse {
+	e := &ErrorResponse{
+		Response:   response,
+		StatusCode: response.StatusCode,
+		ActivityID: response.Header.Get(activityIDHeader),
+		RateLimit:  ParseRateLimit(response),
+	}
+
+	data, err := io.ReadAll(response.Body)
+	if err == nil && len(data) > 0 {
+		_ = json.Unmarshal(data, e)
+	}
+
+	return e
+}
+
+// hasStatus reports whether err is an ErrorResponse with the given status
+func hasStatus(err error, status int) bool {
+	var e *ErrorResponse
+	return errors.As(err, &e) && e.StatusCode == status
+}
+
+// IsTooManyRequests reports whether err was caused by Azure DevOps
+// throttling the request with a 429
+func IsTooManyRequests(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+// IsNotFound reports whether err was caused by the API responding with 404
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsUnauthorized reports whether err was caused by the API responding with
+// 401, which usually means the token is missing, wrong or has expired
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether err was caused by the API responding with 403
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsConflict reports whether err was caused by the API responding with 409
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsBadRequest reports whether err was caused by the API responding with 400,
+// typically a validation failure
+func IsBadRequest(err error) bool {
+	return hasStatus(err, http.StatusBadRequest)
+}
@@ -0,0 +1,117 @@
+package azuredevops_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/benmatselby/go-azuredevops/azuredevops"
+)
+
+const (
+	errorResponseURL  = "/AZURE_DEVOPS_Project/_apis/build/builds"
+	errorResponseBody = `{
+		"$id": "1",
+		"innerException": null,
+		"message": "The requested build 42 could not be found.",
+		"typeName": "Microsoft.TeamFoundation.Build.WebApi.BuildNotFoundException, Microsoft.TeamFoundation.Build2.WebApi",
+		"typeKey": "BuildNotFoundException",
+		"errorCode": 0,
+		"eventId": 3000
+	}`
+)
+
+func TestErrorResponse(t *testing.T) {
+	tt := []struct {
+		name         string
+		status       int
+		response     string
+		typeKey      string
+		message      string
+		eventID      int
+		notFound     bool
+		unauthorized bool
+		conflict     bool
+	}{
+		{name: "decodes the azure devops payload", status: http.StatusNotFound, response: errorResponseBody, typeKey: "BuildNotFoundException", message: "The requested build 42 could not be found.", eventID: 3000, notFound: true},
+		{name: "handles an empty body", status: http.StatusUnauthorized, response: "", unauthorized: true},
+		{name: "handles a body that is not json", status: http.StatusConflict, response: "<html>nope</html>", conflict: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c, mux, _, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc(errorResponseURL, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("ActivityId", "a8c47b4e-2f0c-4a5a-9b39-5fa2f2e0e1a1")
+				w.WriteHeader(tc.status)
+				fmt.Fprint(w, tc.response)
+			})
+
+			_, _, err := c.Builds.List(context.Background(), nil)
+			if err == nil {
+				t.Fatalf("expected an error, did not get one")
+			}
+
+			var e *azuredevops.ErrorResponse
+			if !errors.As(err, &e) {
+				t.Fatalf("expected an ErrorResponse, got %T", err)
+			}
+
+			if e.StatusCode != tc.status {
+				t.Fatalf("expected status %d, got %d", tc.status, e.StatusCode)
+			}
+
+			if e.TypeKey != tc.typeKey {
+				t.Fatalf("expected type key %s, got %s", tc.typeKey, e.TypeKey)
+			}
+
+			if e.Message != tc.message {
+				t.Fatalf("expected message %s, got %s", tc.message, e.Message)
+			}
+
+			if e.EventID != tc.eventID {
+				t.Fatalf("expected event id %d, got %d", tc.eventID, e.EventID)
+			}
+
+			if e.ActivityID != "a8c47b4e-2f0c-4a5a-9b39-5fa2f2e0e1a1" {
+				t.Fatalf("expected activity id to be read from the header, got %s", e.ActivityID)
+			}
+
+			if e.Response == nil || e.Response.Request == nil || e.Response.Request.Method != "GET" {
+				t.Fatalf("expected the originating request to be available")
+			}
+
+			if !strings.Contains(err.Error(), fmt.Sprintf("%d", tc.status)) {
+				t.Fatalf("expected error message to contain the status, got %s", err.Error())
+			}
+
+			if azuredevops.IsNotFound(err) != tc.notFound {
+				t.Fatalf("expected IsNotFound to be %v", tc.notFound)
+			}
+
+			if azuredevops.IsUnauthorized(err) != tc.unauthorized {
+				t.Fatalf("expected IsUnauthorized to be %v", tc.unauthorized)
+			}
+
+			if azuredevops.IsConflict(err) != tc.conflict {
+				t.Fatalf("expected IsConflict to be %v", tc.conflict)
+			}
+		})
+	}
+}
+
+func TestIsNotFound_WrappedError(t *testing.T) {
+	err := fmt.Errorf("fetching build: %w", &azuredevops.ErrorResponse{StatusCode: http.StatusNotFound})
+	if !azuredevops.IsNotFound(err) {
+		t.Fatalf("expected a wrapped 404 to be reported as not found")
+	}
+
+	if azuredevops.IsNotFound(errors.New("boom")) {
+		t.Fatalf("expected a plain error not to be reported as not found")
+	}
+}
@@ -1,6 +1,7 @@
 package azuredevops
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -25,13 +26,13 @@ type Favourite struct {
 }
 
 // List returns a list of the favourite items from for the user
-func (s *FavouritesService) List() ([]Favourite, int, error) {
+func (s *FavouritesService) List(ctx context.Context) ([]Favourite, int, error) {
 	URL := fmt.Sprintf(
 		"/_apis/Favorite/Favorites?artifactType=%s",
 		"Microsoft.TeamFoundation.Git.Repository", // @todo This needs fixing
 	)
 
-	request, err := s.client.NewBaseRequest("GET", URL, nil)
+	request, err := s.client.NewBaseRequestWithContext(ctx, "GET", URL, nil)
 	if err != nil {
 		return nil, 0, err
 	}
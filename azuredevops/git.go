@@ -1,8 +1,8 @@
 package azuredevops
 
 import (
+	"context"
 	"fmt"
-	"time"
 )
 
 // GitService handles communication with the git methods on the API
@@ -30,7 +30,7 @@ type Ref struct {
 			Name  string `json:"name,omitempty"`
 			Genre string `json:"genre,omitempty"`
 		} `json:"context,omitempty"`
-		CreationDate time.Time `json:"creationDate,omitempty"`
+		CreationDate Time `json:"creationDate,omitempty"`
 		CreatedBy    struct {
 			ID          string `json:"id,omitempty"`
 			DisplayName string `json:"displayName,omitempty"`
@@ -47,24 +47,56 @@ type GitRefListOptions struct {
 	Filter             string `url:"filter,omitempty"`
 	IncludeStatuses    bool   `url:"includeStatuses,omitempty"`
 	LatestStatusesOnly bool   `url:"latestStatusesOnly,omitempty"`
+	Top                int    `url:"$top,omitempty"`
+	Token              string `url:"continuationToken,omitempty"`
 }
 
-// ListRefs returns a list of the references for a git repo
-func (s *GitService) ListRefs(repo, refType string, opts *GitRefListOptions) ([]Ref, int, error) {
+// ListRefs returns a list of the references for a git repo along with the
+// continuation token for the next page, which is empty when there are no
+// more references
+// utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/git/refs/list
+func (s *GitService) ListRefs(ctx context.Context, repo, refType string, opts *GitRefListOptions) ([]Ref, int, string, error) {
 	URL := fmt.Sprintf(
-		"/_apis/git/repositories/%s/refs/%s?api-version=4.1",
+		"/_apis/git/repositories/%s/refs/%s?api-version=%s",
 		repo,
 		refType,
+		s.client.APIVersion(GitRefsAPI),
 	)
 
 	URL, err := addOptions(URL, opts)
 
-	request, err := s.client.NewRequest("GET", URL, nil)
+	request, err := s.client.NewRequestWithContext(ctx, "GET", URL, nil)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, "", err
 	}
 	var response GitListRefsResponse
-	_, err = s.client.Execute(request, &response)
+	resp, err := s.client.Execute(request, &response)
 
-	return response.Refs, response.Count, err
+	return response.Refs, response.Count, continuationToken(resp), err
+}
+
+// RefsPager returns a Pager that walks every page of references matching
+// opts
+func (s *GitService) RefsPager(repo, refType string, opts *GitRefListOptions) *Pager[Ref] {
+	return NewPager(s.listRefsPage(repo, refType, opts))
+}
+
+// ListAllRefs returns every reference matching opts, following continuation
+// tokens
+func (s *GitService) ListAllRefs(ctx context.Context, repo, refType string, opts *GitRefListOptions) ([]Ref, error) {
+	return ListAll(ctx, s.listRefsPage(repo, refType, opts))
+}
+
+func (s *GitService) listRefsPage(repo, refType string, opts *GitRefListOptions) PageFunc[Ref] {
+	return func(ctx context.Context, token string) ([]Ref, string, error) {
+		pageOpts := GitRefListOptions{}
+		if opts != nil {
+			pageOpts = *opts
+		}
+		if token != "" {
+			pageOpts.Token = token
+		}
+		refs, _, next, err := s.ListRefs(ctx, repo, refType, &pageOpts)
+		return refs, next, err
+	}
 }
@@ -1,6 +1,7 @@
 package azuredevops_test
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"testing"
@@ -73,7 +74,7 @@ func TestGitService_ListRefs(t *testing.T) {
 			})
 
 			opts := azuredevops.GitRefListOptions{}
-			refs, count, err := c.Git.ListRefs("vscode", "heads", &opts)
+			refs, count, _, err := c.Git.ListRefs(context.Background(), "vscode", "heads", &opts)
 			if err != nil {
 				t.Fatalf("returned error: %v", err)
 			}
@@ -97,3 +98,43 @@ func TestGitService_ListRefs(t *testing.T) {
 		})
 	}
 }
+
+func TestGitService_ListAllRefs(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(gitRefsListURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch r.URL.Query().Get("continuationToken") {
+		case "":
+			w.Header().Set("x-ms-continuationtoken", "page-2")
+			fmt.Fprint(w, `{"count": 2, "value": [{"name": "refs/heads/develop"}, {"name": "refs/heads/main"}]}`)
+		case "page-2":
+			fmt.Fprint(w, `{"count": 1, "value": [{"name": "refs/heads/release"}]}`)
+		default:
+			t.Fatalf("unexpected continuation token %s", r.URL.Query().Get("continuationToken"))
+		}
+	})
+
+	refs, _, token, err := c.Git.ListRefs(context.Background(), "vscode", "heads", nil)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if token != "page-2" {
+		t.Fatalf("expected continuation token page-2, got %s", token)
+	}
+
+	if len(refs) != 2 {
+		t.Fatalf("expected length of first page to be 2; got %d", len(refs))
+	}
+
+	refs, err = c.Git.ListAllRefs(context.Background(), "vscode", "heads", &azuredevops.GitRefListOptions{Top: 2})
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(refs) != 3 || refs[2].Name != "refs/heads/release" {
+		t.Fatalf("expected the refs from both pages; got %v", refs)
+	}
+}
@@ -0,0 +1,124 @@
+package azuredevops
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// Host identifies which Azure DevOps host an API is served from. On
+// Azure DevOps Services some areas live on their own sub-host, whereas
+// Azure DevOps Server serves everything from the collection URL
+type Host int
+
+const (
+	// HostCore is the main host, https://dev.azure.com/{organization}
+	HostCore Host = iota
+	// HostReleases serves the release management APIs, vsrm.dev.azure.com
+	HostReleases
+	// HostIdentity serves the identity and graph APIs, vssps.dev.azure.com
+	HostIdentity
+	// HostSearch serves the search APIs, almsearch.dev.azure.com
+	HostSearch
+	// HostFeeds serves the artifacts and packaging APIs, feeds.dev.azure.com
+	HostFeeds
+)
+
+// subdomains maps each host to the sub-host prefix used by Azure DevOps Services
+var subdomains = map[Host]string{
+	HostReleases: "vsrm",
+	HostIdentity: "vssps",
+	HostSearch:   "almsearch",
+	HostFeeds:    "feeds",
+}
+
+// WithHostURL overrides the URL used for host, for when the default
+// resolution from BaseURL is wrong for your setup
+func WithHostURL(host Host, URL string) ClientOption {
+	return func(c *Client) {
+		if c.HostURLs == nil {
+			c.HostURLs = map[Host]string{}
+		}
+		c.HostURLs[host] = strings.TrimSuffix(URL, "/")
+	}
+}
+
+// NewCollectionClient gets a new Azure DevOps Client for an arbitrary
+// organization or collection URL. This supports Azure DevOps Services, e.g.
+// https://dev.azure.com/myorg or https://myorg.visualstudio.com, and
+// Azure DevOps Server, e.g. https://tfs.corp/tfs/DefaultCollection
+func NewCollectionClient(collectionURL string, project string, token string, opts ...ClientOption) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(collectionURL, "/"))
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("collection URL %q must be absolute", collectionURL)
+	}
+
+	c := NewClient("", project, token, opts...)
+	c.BaseURL = u.String()
+	c.Account = accountFromURL(u)
+
+	return c, nil
+}
+
+// accountFromURL works out the organization or collection name from u
+func accountFromURL(u *url.URL) string {
+	if strings.HasSuffix(u.Hostname(), ".visualstudio.com") {
+		return strings.SplitN(u.Hostname(), ".", 2)[0]
+	}
+	return path.Base("/" + strings.Trim(u.Path, "/"))
+}
+
+// HostURL returns the base URL for host. Unless it has been set with
+// WithHostURL, it is derived from BaseURL: Azure DevOps Services URLs gain
+// the sub-host, any other URL is assumed to be Azure DevOps Server and is
+// returned unchanged
+func (c *Client) HostURL(host Host) string {
+	if u, ok := c.HostURLs[host]; ok {
+		return u
+	}
+
+	sub, ok := subdomains[host]
+	if !ok {
+		return c.BaseURL
+	}
+
+	u, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return c.BaseURL
+	}
+
+	switch {
+	case u.Hostname() == "dev.azure.com":
+		u.Host = sub + "." + u.Host
+	case strings.HasSuffix(u.Hostname(), ".visualstudio.com"):
+		parts := strings.SplitN(u.Host, ".", 2)
+		u.Host = parts[0] + "." + sub + "." + parts[1]
+	}
+
+	return u.String()
+}
+
+// knownHost reports whether u is on BaseURL or one of the hosts, and so may
+// be sent the Client's credentials. URLs handed back by the API, such as
+// artifact download URLs, can point anywhere
+func (c *Client) knownHost(u *url.URL) bool {
+	known := []string{c.BaseURL, c.HostURL(HostCore)}
+	for host := range subdomains {
+		known = append(known, c.HostURL(host))
+	}
+
+	for _, k := range known {
+		base, err := url.Parse(k)
+		if err != nil {
+			continue
+		}
+		if strings.EqualFold(base.Scheme, u.Scheme) && strings.EqualFold(base.Host, u.Host) {
+			return true
+		}
+	}
+	return false
+}
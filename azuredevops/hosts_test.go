@@ -0,0 +1,108 @@
+package azuredevops_test
+
+import (
+	"testing"
+
+	"github.com/benmatselby/go-azuredevops/azuredevops"
+)
+
+func TestNewCollectionClient(t *testing.T) {
+	tt := []struct {
+		name          string
+		collectionURL string
+		baseURL       string
+		account       string
+		releases      string
+		identity      string
+		search        string
+		feeds         string
+	}{
+		{
+			name:          "azure devops services",
+			collectionURL: "https://dev.azure.com/fabrikam/",
+			baseURL:       "https://dev.azure.com/fabrikam",
+			account:       "fabrikam",
+			releases:      "https://vsrm.dev.azure.com/fabrikam",
+			identity:      "https://vssps.dev.azure.com/fabrikam",
+			search:        "https://almsearch.dev.azure.com/fabrikam",
+			feeds:         "https://feeds.dev.azure.com/fabrikam",
+		},
+		{
+			name:          "legacy visualstudio.com host",
+			collectionURL: "https://fabrikam.visualstudio.com",
+			baseURL:       "https://fabrikam.visualstudio.com",
+			account:       "fabrikam",
+			releases:      "https://fabrikam.vsrm.visualstudio.com",
+			identity:      "https://fabrikam.vssps.visualstudio.com",
+			search:        "https://fabrikam.almsearch.visualstudio.com",
+			feeds:         "https://fabrikam.feeds.visualstudio.com",
+		},
+		{
+			name:          "azure devops server",
+			collectionURL: "https://tfs.corp/tfs/DefaultCollection",
+			baseURL:       "https://tfs.corp/tfs/DefaultCollection",
+			account:       "DefaultCollection",
+			releases:      "https://tfs.corp/tfs/DefaultCollection",
+			identity:      "https://tfs.corp/tfs/DefaultCollection",
+			search:        "https://tfs.corp/tfs/DefaultCollection",
+			feeds:         "https://tfs.corp/tfs/DefaultCollection",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := azuredevops.NewCollectionClient(tc.collectionURL, "AZURE_DEVOPS_Project", "AZURE_DEVOPS_TOKEN")
+			if err != nil {
+				t.Fatalf("returned error: %v", err)
+			}
+
+			if c.BaseURL != tc.baseURL {
+				t.Fatalf("expected base url %s; got %s", tc.baseURL, c.BaseURL)
+			}
+
+			if c.Account != tc.account {
+				t.Fatalf("expected account %s; got %s", tc.account, c.Account)
+			}
+
+			hosts := map[azuredevops.Host]string{
+				azuredevops.HostCore:     tc.baseURL,
+				azuredevops.HostReleases: tc.releases,
+				azuredevops.HostIdentity: tc.identity,
+				azuredevops.HostSearch:   tc.search,
+				azuredevops.HostFeeds:    tc.feeds,
+			}
+			for host, want := range hosts {
+				if got := c.HostURL(host); got != want {
+					t.Fatalf("expected host %d url %s; got %s", host, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestNewCollectionClient_RelativeURL(t *testing.T) {
+	_, err := azuredevops.NewCollectionClient("tfs/DefaultCollection", "AZURE_DEVOPS_Project", "AZURE_DEVOPS_TOKEN")
+	if err == nil {
+		t.Fatalf("expected an error for a relative url, did not get one")
+	}
+}
+
+func TestWithHostURL(t *testing.T) {
+	c := azuredevops.NewClient(
+		"fabrikam", "AZURE_DEVOPS_Project", "AZURE_DEVOPS_TOKEN",
+		azuredevops.WithHostURL(azuredevops.HostReleases, "https://releases.corp/"),
+	)
+
+	if got := c.HostURL(azuredevops.HostReleases); got != "https://releases.corp" {
+		t.Fatalf("expected the overridden release host; got %s", got)
+	}
+
+	request, err := c.NewBaseRequest("GET", "/_apis/projects", nil)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if got := request.URL.String(); got != "https://dev.azure.com/fabrikam/_apis/projects" {
+		t.Fatalf("expected the core host to be unchanged; got %s", got)
+	}
+}
@@ -1,6 +1,7 @@
 package azuredevops
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 )
@@ -22,20 +23,21 @@ type Iteration struct {
 	Name      string          `json:"name"`
 	Path      string          `json:"path"`
 	URL       string          `json:"url"`
-	StartDate string          `json:"startDate,omitempty"`
-	EndDate   string          `json:"finishDate,omitempty"`
+	StartDate Time            `json:"startDate,omitempty"`
+	EndDate   Time            `json:"finishDate,omitempty"`
 	WorkItems [][]interface{} `json:"workItems,omitempty"`
 }
 
 // List returns list of the iterations available to the user
 // utilising https://docs.microsoft.com/en-gb/rest/api/vsts/work/iterations/list
-func (s *IterationsService) List(team string) ([]Iteration, error) {
+func (s *IterationsService) List(ctx context.Context, team string) ([]Iteration, error) {
 	URL := fmt.Sprintf(
-		"/%s/_apis/work/teamsettings/iterations?api-version=4.1-preview",
+		"/%s/_apis/work/teamsettings/iterations?api-version=%s",
 		url.PathEscape(team),
+		s.client.APIVersion(IterationsAPI),
 	)
 
-	request, err := s.client.NewRequest("GET", URL, nil)
+	request, err := s.client.NewRequestWithContext(ctx, "GET", URL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -47,8 +49,8 @@ func (s *IterationsService) List(team string) ([]Iteration, error) {
 
 // GetByName will search the iterations for the account and project
 // and return a single iteration if the names match
-func (s *IterationsService) GetByName(team string, name string) (*Iteration, error) {
-	iterations, err := s.List(team)
+func (s *IterationsService) GetByName(ctx context.Context, team string, name string) (*Iteration, error) {
+	iterations, err := s.List(ctx, team)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package azuredevops_test
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"testing"
@@ -47,7 +48,7 @@ func TestIterationService_GetByName(t *testing.T) {
 				fmt.Fprint(w, json)
 			})
 
-			iteration, err := c.Iterations.GetByName("AZURE_DEVOPS_TEAM", tc.iteration)
+			iteration, err := c.Iterations.GetByName(context.Background(), "AZURE_DEVOPS_TEAM", tc.iteration)
 			if err != nil {
 				t.Fatalf("returned error: %v", err)
 			}
@@ -90,7 +91,7 @@ func TestIterationService_List(t *testing.T) {
 				fmt.Fprint(w, json)
 			})
 
-			iterations, err := c.Iterations.List("AZURE_DEVOPS_TEAM")
+			iterations, err := c.Iterations.List(context.Background(), "AZURE_DEVOPS_TEAM")
 			if err != nil {
 				t.Fatalf("returned error: %v", err)
 			}
@@ -0,0 +1,100 @@
+package azuredevops
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+// decodeJSON decodes data into a generic value, keeping numbers exactly as
+// they were sent
+func decodeJSON(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// encodeJSON encodes a generic value with its object keys sorted, without
+// escaping HTML
+func encodeJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// mergeJSON applies the changes between base and current to raw. raw is the
+// document the API sent, base is what the model made of it and current is
+// the model now, so fields the model doesn't know about, or drops, are kept
+// from raw while the fields that were changed are taken from current
+func mergeJSON(raw, base, current []byte) ([]byte, error) {
+	var values [3]interface{}
+	for i, data := range [][]byte{raw, base, current} {
+		v, err := decodeJSON(data)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+
+	return encodeJSON(mergeValues(values[0], values[1], values[2]))
+}
+
+// mergeValues is mergeJSON for decoded values
+func mergeValues(raw, base, current interface{}) interface{} {
+	if reflect.DeepEqual(base, current) {
+		return raw
+	}
+
+	rawObject, okRaw := raw.(map[string]interface{})
+	baseObject, okBase := base.(map[string]interface{})
+	currentObject, okCurrent := current.(map[string]interface{})
+	if okRaw && okBase && okCurrent {
+		merged := make(map[string]interface{}, len(rawObject))
+		for key, value := range rawObject {
+			merged[key] = value
+		}
+
+		for key, baseValue := range baseObject {
+			if _, ok := currentObject[key]; !ok {
+				// The model left the field out, so it has been cleared
+				delete(merged, key)
+				continue
+			}
+			if rawValue, ok := rawObject[key]; ok {
+				merged[key] = mergeValues(rawValue, baseValue, currentObject[key])
+			} else if !reflect.DeepEqual(baseValue, currentObject[key]) {
+				merged[key] = currentObject[key]
+			}
+		}
+		for key, currentValue := range currentObject {
+			if _, ok := baseObject[key]; !ok {
+				merged[key] = currentValue
+			}
+		}
+		return merged
+	}
+
+	// Arrays are merged element by element while nothing has been added or
+	// removed, otherwise there is no telling which elements match
+	rawArray, okRaw := raw.([]interface{})
+	baseArray, okBase := base.([]interface{})
+	currentArray, okCurrent := current.([]interface{})
+	if okRaw && okBase && okCurrent && len(rawArray) == len(baseArray) && len(baseArray) == len(currentArray) {
+		merged := make([]interface{}, len(rawArray))
+		for i := range rawArray {
+			merged[i] = mergeValues(rawArray[i], baseArray[i], currentArray[i])
+		}
+		return merged
+	}
+
+	return current
+}
@@ -0,0 +1,273 @@
+package azuredevops
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Doer sends a request and returns its response, as http.Client does
+type Doer interface {
+	Do(request *http.Request) (*http.Response, error)
+}
+
+// DoerFunc adapts a function to a Doer
+type DoerFunc func(request *http.Request) (*http.Response, error)
+
+// Do calls f(request)
+func (f DoerFunc) Do(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+// Middleware wraps a Doer to observe or change requests and responses. It
+// runs once for each attempt, so retries are seen individually
+type Middleware func(next Doer) Doer
+
+// WithMiddleware adds middleware to the Client. The first middleware given
+// is the outermost, so it sees the request first and the response last
+func WithMiddleware(middleware ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.Middleware = append(c.Middleware, middleware...)
+	}
+}
+
+// doer returns the http.Client wrapped in the Client's middleware
+func (c *Client) doer() Doer {
+	var d Doer = c.HTTPClient()
+	for i := len(c.Middleware) - 1; i >= 0; i-- {
+		d = c.Middleware[i](d)
+	}
+	return d
+}
+
+// Operation describes which part of the API a request is for, taken from
+// the path after _apis, e.g. _apis/build/builds has Area "build" and
+// Resource "builds"
+type Operation struct {
+	Area     string
+	Resource string
+}
+
+// String returns the operation as area/resource
+func (o Operation) String() string {
+	if o.Resource == "" {
+		return o.Area
+	}
+	return o.Area + "/" + o.Resource
+}
+
+// OperationFor returns the Operation for request
+func OperationFor(request *http.Request) Operation {
+	parts := strings.Split(request.URL.Path, "/")
+	for i, part := range parts {
+		if !strings.EqualFold(part, "_apis") {
+			continue
+		}
+		var op Operation
+		if i+1 < len(parts) {
+			op.Area = strings.ToLower(parts[i+1])
+		}
+		if i+2 < len(parts) {
+			op.Resource = strings.ToLower(parts[i+2])
+		}
+		return op
+	}
+	return Operation{}
+}
+
+// redacted replaces secrets in logs
+const redacted = "REDACTED"
+
+// sensitiveQueryParams are query parameters whose values are never logged
+var sensitiveQueryParams = []string{"access_token", "token", "code", "client_secret"}
+
+// RedactURL returns u as a string with credentials and tokens removed
+func RedactURL(u *url.URL) string {
+	clean := *u
+	if clean.User != nil {
+		clean.User = url.User(redacted)
+	}
+
+	query := clean.Query()
+	changed := false
+	for _, param := range sensitiveQueryParams {
+		if query.Has(param) {
+			query.Set(param, redacted)
+			changed = true
+		}
+	}
+	if changed {
+		clean.RawQuery = query.Encode()
+	}
+
+	return clean.String()
+}
+
+// LoggingMiddleware logs each request and its outcome to logger. The
+// Authorization header and any token in the URL are never logged
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(request *http.Request) (*http.Response, error) {
+			ctx := request.Context()
+			start := time.Now()
+			attrs := []slog.Attr{
+				slog.String("method", request.Method),
+				slog.String("url", RedactURL(request.URL)),
+				slog.String("operation", OperationFor(request).String()),
+			}
+
+			response, err := next.Do(request)
+
+			attrs = append(attrs, slog.Duration("duration", time.Since(start)))
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+				logger.LogAttrs(ctx, slog.LevelError, "azuredevops request failed", attrs...)
+				return response, err
+			}
+
+			attrs = append(attrs,
+				slog.Int("status", response.StatusCode),
+				slog.String("activity_id", response.Header.Get(activityIDHeader)),
+			)
+
+			level := slog.LevelDebug
+			if response.StatusCode >= 400 {
+				level = slog.LevelWarn
+			}
+			logger.LogAttrs(ctx, level, "azuredevops request", attrs...)
+
+			return response, err
+		})
+	}
+}
+
+// MetricsRecorder receives a measurement for every request. Status is 0 when
+// the request failed without a response
+type MetricsRecorder interface {
+	RecordRequest(op Operation, method string, status int, duration time.Duration)
+}
+
+// MetricsMiddleware reports the latency and status of each request to recorder
+func MetricsMiddleware(recorder MetricsRecorder) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(request *http.Request) (*http.Response, error) {
+			start := time.Now()
+			response, err := next.Do(request)
+
+			status := 0
+			if response != nil {
+				status = response.StatusCode
+			}
+			recorder.RecordRequest(OperationFor(request), request.Method, status, time.Since(start))
+
+			return response, err
+		})
+	}
+}
+
+// EndpointStats is the summary EndpointMetrics keeps for an endpoint
+type EndpointStats struct {
+	Count         int
+	TotalDuration time.Duration
+	MaxDuration   time.Duration
+	Statuses      map[int]int
+}
+
+// EndpointMetrics is an in-memory MetricsRecorder keyed by method and
+// operation, e.g. "GET build/builds"
+type EndpointMetrics struct {
+	mu    sync.Mutex
+	stats map[string]*EndpointStats
+}
+
+// RecordRequest adds a measurement
+func (m *EndpointMetrics) RecordRequest(op Operation, method string, status int, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.stats == nil {
+		m.stats = map[string]*EndpointStats{}
+	}
+
+	key := method + " " + op.String()
+	s, ok := m.stats[key]
+	if !ok {
+		s = &EndpointStats{Statuses: map[int]int{}}
+		m.stats[key] = s
+	}
+
+	s.Count++
+	s.TotalDuration += duration
+	if duration > s.MaxDuration {
+		s.MaxDuration = duration
+	}
+	s.Statuses[status]++
+}
+
+// Snapshot returns a copy of the stats recorded so far
+func (m *EndpointMetrics) Snapshot() map[string]EndpointStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[string]EndpointStats, len(m.stats))
+	for key, s := range m.stats {
+		statuses := make(map[int]int, len(s.Statuses))
+		for status, count := range s.Statuses {
+			statuses[status] = count
+		}
+		copied := *s
+		copied.Statuses = statuses
+		snapshot[key] = copied
+	}
+	return snapshot
+}
+
+// Span is the part of a tracing span TracingMiddleware needs. It matches the
+// shape of an OpenTelemetry span so an adapter is a few lines
+type Span interface {
+	SetAttributes(attrs map[string]string)
+	RecordError(err error)
+	End()
+}
+
+// Tracer starts spans for TracingMiddleware
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// TracingMiddleware wraps each request in a span named after its operation,
+// tagged with the HTTP method and status and the Azure DevOps activity ID
+func TracingMiddleware(tracer Tracer) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(request *http.Request) (*http.Response, error) {
+			op := OperationFor(request)
+			ctx, span := tracer.Start(request.Context(), "azuredevops "+op.String())
+			defer span.End()
+
+			span.SetAttributes(map[string]string{
+				"http.method":           request.Method,
+				"http.url":              RedactURL(request.URL),
+				"azuredevops.area":      op.Area,
+				"azuredevops.resource":  op.Resource,
+				"azuredevops.operation": op.String(),
+			})
+
+			response, err := next.Do(request.WithContext(ctx))
+			if err != nil {
+				span.RecordError(err)
+				return response, err
+			}
+
+			span.SetAttributes(map[string]string{
+				"http.status_code":        strconv.Itoa(response.StatusCode),
+				"azuredevops.activity_id": response.Header.Get(activityIDHeader),
+			})
+			return response, err
+		})
+	}
+}
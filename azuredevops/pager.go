@@ -0,0 +1,108 @@
+package azuredevops
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+// continuationTokenHeader is the header list endpoints use to hand back the
+// token for the next page of results
+const continuationTokenHeader = "x-ms-continuationtoken"
+
+// defaultPageSize is used when walking a $top/$skip paged endpoint without
+// the caller asking for a page size, as the API does not say whether
+// there are more results
+const defaultPageSize = 100
+
+// PageFunc fetches the page of results identified by token, returning the
+// token for the following page, or an empty string on the last page. The
+// first page is requested with an empty token
+type PageFunc[T any] func(ctx context.Context, token string) ([]T, string, error)
+
+// Pager walks the pages of a list endpoint lazily, one request per call to
+// NextPage. For $skip based endpoints the token is the offset of the next page
+type Pager[T any] struct {
+	fetch PageFunc[T]
+	token string
+	done  bool
+}
+
+// NewPager returns a Pager that fetches each page with fetch
+func NewPager[T any](fetch PageFunc[T]) *Pager[T] {
+	return &Pager[T]{fetch: fetch}
+}
+
+// More reports whether there are pages left to fetch
+func (p *Pager[T]) More() bool {
+	return !p.done
+}
+
+// Token returns the token that will be used to fetch the next page
+func (p *Pager[T]) Token() string {
+	return p.token
+}
+
+// NextPage fetches the next page of results. A failed request can be
+// retried by calling NextPage again
+func (p *Pager[T]) NextPage(ctx context.Context) ([]T, error) {
+	if p.done {
+		return nil, nil
+	}
+
+	items, next, err := p.fetch(ctx, p.token)
+	if err != nil {
+		return nil, err
+	}
+
+	p.token = next
+	p.done = next == ""
+	return items, nil
+}
+
+// ListAll fetches every page from fetch and returns all the results
+func ListAll[T any](ctx context.Context, fetch PageFunc[T]) ([]T, error) {
+	var all []T
+	pager := NewPager(fetch)
+	for pager.More() {
+		items, err := pager.NextPage(ctx)
+		if err != nil {
+			return all, err
+		}
+		all = append(all, items...)
+	}
+	return all, nil
+}
+
+// continuationToken reads the next page token from response
+func continuationToken(response *http.Response) string {
+	if response == nil {
+		return ""
+	}
+	return response.Header.Get(continuationTokenHeader)
+}
+
+// skipPage converts a $skip pager token into an offset and works out the
+// page size to ask for
+func skipPage(token string, top int) (skip int, size int, err error) {
+	if token != "" {
+		skip, err = strconv.Atoi(token)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	size = top
+	if size <= 0 {
+		size = defaultPageSize
+	}
+	return skip, size, nil
+}
+
+// nextSkip returns the pager token for the page after one that started at
+// skip and returned count of the size asked for
+func nextSkip(skip, size, count int) string {
+	if count < size {
+		return ""
+	}
+	return strconv.Itoa(skip + count)
+}
@@ -0,0 +1,56 @@
+package azuredevops_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/benmatselby/go-azuredevops/azuredevops"
+)
+
+func TestListAll(t *testing.T) {
+	pages := map[string][]int{"": {1, 2}, "b": {3}, "c": {4, 5}}
+	next := map[string]string{"": "b", "b": "c", "c": ""}
+
+	fetch := func(ctx context.Context, token string) ([]int, string, error) {
+		return pages[token], next[token], nil
+	}
+
+	all, err := azuredevops.ListAll(context.Background(), fetch)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(all) != 5 {
+		t.Fatalf("expected 5 items; got %d", len(all))
+	}
+}
+
+func TestPager_NextPageError(t *testing.T) {
+	calls := 0
+	fetch := func(ctx context.Context, token string) ([]int, string, error) {
+		calls++
+		if calls == 1 {
+			return nil, "", errors.New("boom")
+		}
+		return []int{1}, "", nil
+	}
+
+	pager := azuredevops.NewPager(fetch)
+	if _, err := pager.NextPage(context.Background()); err == nil {
+		t.Fatalf("expected an error, did not get one")
+	}
+
+	if !pager.More() {
+		t.Fatalf("expected a failed page to be retryable")
+	}
+
+	items, err := pager.NextPage(context.Background())
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(items) != 1 || pager.More() {
+		t.Fatalf("expected the last page to be returned and the pager to be done")
+	}
+}
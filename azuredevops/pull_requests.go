@@ -1,6 +1,10 @@
 package azuredevops
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
 
 // PullRequestsService handles communication with the pull requests methods on the API
 // utilising https://docs.microsoft.com/en-us/rest/api/vsts/git/pull%20requests
@@ -16,13 +20,13 @@ type PullRequestsResponse struct {
 
 // PullRequest describes the pull request
 type PullRequest struct {
-	ID          int             `json:"pullRequestId,omitempty"`
-	Title       string          `json:"title"`
-	Description string          `json:"description"`
-	Status      string          `json:"status"`
-	Created     string          `json:"creationDate"`
-	Repo        PullRequestRepo `json:"repository"`
-	URL         string          `json:"url"`
+	ID          int               `json:"pullRequestId,omitempty"`
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	Status      PullRequestStatus `json:"status"`
+	Created     Time              `json:"creationDate"`
+	Repo        PullRequestRepo   `json:"repository"`
+	URL         string            `json:"url"`
 }
 
 // PullRequestRepo describes the repo within the pull request
@@ -35,16 +39,59 @@ type PullRequestRepo struct {
 // PullRequestListOptions describes what the request to the API should look like
 type PullRequestListOptions struct {
 	// https://docs.microsoft.com/en-us/rest/api/vsts/git/pull%20requests/get%20pull%20requests%20by%20project#pullrequeststatus
-	State string `url:"searchCriteria.status,omitempty"`
+	State PullRequestStatus `url:"searchCriteria.status,omitempty"`
+	Top   int               `url:"$top,omitempty"`
+	Skip  int               `url:"$skip,omitempty"`
+}
+
+// PullRequestStatus is enum type for the status of a pull request
+type PullRequestStatus string
+
+const (
+	// PullRequestStatusNotSet is a pull request with no status
+	PullRequestStatusNotSet PullRequestStatus = "notSet"
+	// PullRequestStatusActive is an open pull request
+	PullRequestStatusActive PullRequestStatus = "active"
+	// PullRequestStatusAbandoned is a pull request that was abandoned
+	PullRequestStatusAbandoned PullRequestStatus = "abandoned"
+	// PullRequestStatusCompleted is a pull request that was merged
+	PullRequestStatusCompleted PullRequestStatus = "completed"
+	// PullRequestStatusAll matches every status when filtering
+	PullRequestStatusAll PullRequestStatus = "all"
+)
+
+var pullRequestStatuses = []PullRequestStatus{
+	PullRequestStatusNotSet, PullRequestStatusActive, PullRequestStatusAbandoned,
+	PullRequestStatusCompleted, PullRequestStatusAll,
+}
+
+// String returns the status as the API spells it
+func (s PullRequestStatus) String() string {
+	return string(s)
+}
+
+// Valid reports whether s is a known pull request status
+func (s PullRequestStatus) Valid() bool {
+	return validEnum(s, pullRequestStatuses)
+}
+
+// UnmarshalJSON decodes a pull request status, ignoring case
+func (s *PullRequestStatus) UnmarshalJSON(data []byte) (err error) {
+	*s, err = unmarshalEnum(data, pullRequestStatuses)
+	return err
 }
 
 // List returns list of the pull requests
 // utilising https://docs.microsoft.com/en-us/rest/api/vsts/git/pull%20requests/get%20pull%20requests%20by%20project
-func (s *PullRequestsService) List(opts *PullRequestListOptions) ([]PullRequest, int, error) {
-	URL := fmt.Sprintf("/_apis/git/pullrequests?api-version=4.1")
+func (s *PullRequestsService) List(ctx context.Context, opts *PullRequestListOptions) ([]PullRequest, int, error) {
+	if opts != nil && opts.State != "" && !opts.State.Valid() {
+		return nil, 0, fmt.Errorf("azuredevops: invalid pull request status %q", opts.State)
+	}
+
+	URL := fmt.Sprintf("/_apis/git/pullrequests?api-version=%s", s.client.APIVersion(PullRequestsAPI))
 	URL, err := addOptions(URL, opts)
 
-	request, err := s.client.NewRequest("GET", URL, nil)
+	request, err := s.client.NewRequestWithContext(ctx, "GET", URL, nil)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -53,3 +100,38 @@ func (s *PullRequestsService) List(opts *PullRequestListOptions) ([]PullRequest,
 
 	return response.PullRequests, response.Count, err
 }
+
+// Pager returns a Pager that walks every page of pull requests matching opts
+// using $top and $skip. The pager token is the $skip offset of the next page
+func (s *PullRequestsService) Pager(opts *PullRequestListOptions) *Pager[PullRequest] {
+	return NewPager(s.listPage(opts))
+}
+
+// ListAll returns every pull request matching opts, one page at a time
+func (s *PullRequestsService) ListAll(ctx context.Context, opts *PullRequestListOptions) ([]PullRequest, error) {
+	return ListAll(ctx, s.listPage(opts))
+}
+
+func (s *PullRequestsService) listPage(opts *PullRequestListOptions) PageFunc[PullRequest] {
+	return func(ctx context.Context, token string) ([]PullRequest, string, error) {
+		pageOpts := PullRequestListOptions{}
+		if opts != nil {
+			pageOpts = *opts
+		}
+		if token == "" {
+			token = strconv.Itoa(pageOpts.Skip)
+		}
+		skip, size, err := skipPage(token, pageOpts.Top)
+		if err != nil {
+			return nil, "", err
+		}
+		pageOpts.Skip = skip
+		pageOpts.Top = size
+
+		pullRequests, _, err := s.List(ctx, &pageOpts)
+		if err != nil {
+			return nil, "", err
+		}
+		return pullRequests, nextSkip(skip, size, len(pullRequests)), nil
+	}
+}
@@ -1,9 +1,11 @@
 package azuredevops_test
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/benmatselby/go-azuredevops/azuredevops"
 )
@@ -96,7 +98,7 @@ func TestPullRequestsService_List(t *testing.T) {
 			})
 
 			opt := &azuredevops.PullRequestListOptions{}
-			response, count, err := c.PullRequests.List(opt)
+			response, count, err := c.PullRequests.List(context.Background(), opt)
 			if err != nil {
 				t.Fatalf("returned error: %v", err)
 			}
@@ -113,7 +115,67 @@ func TestPullRequestsService_List(t *testing.T) {
 				if tc.title != response[0].Title {
 					t.Fatalf("expected title to be %s; got %s", tc.title, response[0].Title)
 				}
+
+				created := time.Date(2016, 11, 1, 16, 30, 31, 665547100, time.UTC)
+				if !response[0].Created.Equal(created) {
+					t.Fatalf("expected created to be %v; got %v", created, response[0].Created)
+				}
 			}
 		})
 	}
 }
+
+func TestPullRequestsService_ListAll(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	var skips []string
+	mux.HandleFunc(pullrequestsListURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.URL.Query().Get("$top") != "2" {
+			t.Fatalf("expected $top to be 2, got %s", r.URL.Query().Get("$top"))
+		}
+		skip := r.URL.Query().Get("$skip")
+		skips = append(skips, skip)
+		switch skip {
+		case "":
+			fmt.Fprint(w, `{"value": [{"pullRequestId": 1}, {"pullRequestId": 2}], "count": 2}`)
+		case "2":
+			fmt.Fprint(w, `{"value": [{"pullRequestId": 3}, {"pullRequestId": 4}], "count": 2}`)
+		case "4":
+			fmt.Fprint(w, `{"value": [{"pullRequestId": 5}], "count": 1}`)
+		default:
+			t.Fatalf("unexpected $skip %s", skip)
+		}
+	})
+
+	pager := c.PullRequests.Pager(&azuredevops.PullRequestListOptions{Top: 2})
+
+	var ids []int
+	for pager.More() {
+		pullRequests, err := pager.NextPage(context.Background())
+		if err != nil {
+			t.Fatalf("returned error: %v", err)
+		}
+		for _, pullRequest := range pullRequests {
+			ids = append(ids, pullRequest.ID)
+		}
+	}
+
+	if fmt.Sprint(skips) != "[ 2 4]" {
+		t.Fatalf("expected the offset to advance by a page and stop after the short page; got %q", skips)
+	}
+
+	if fmt.Sprint(ids) != "[1 2 3 4 5]" {
+		t.Fatalf("expected five pull requests; got %v", ids)
+	}
+
+	all, err := c.PullRequests.ListAll(context.Background(), &azuredevops.PullRequestListOptions{Top: 2, Skip: 2})
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(all) != 3 || all[0].ID != 3 {
+		t.Fatalf("expected the pull requests from the offset on; got %v", all)
+	}
+}
@@ -0,0 +1,230 @@
+package azuredevops
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RetryPolicy describes how the Client retries requests that Azure DevOps
+// throttled or could not serve. Retrying is opt-in, see WithRetryPolicy
+type RetryPolicy struct {
+	// MaxAttempts is the total number of times a request is sent, including
+	// the first attempt. Values below 2 disable retrying
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry, doubled on each
+	// subsequent attempt
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between attempts, including any delay asked
+	// for by the Retry-After or X-RateLimit-Delay headers
+	MaxBackoff time.Duration
+	// RetryNonIdempotent also retries POST and PATCH requests. Only enable
+	// this if a duplicate request is harmless for the endpoints being called
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy is a reasonable policy for bulk API consumers
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	MinBackoff:  500 * time.Millisecond,
+	MaxBackoff:  30 * time.Second,
+}
+
+// WithRetryPolicy makes the Client retry throttled and unavailable responses
+// according to p
+func WithRetryPolicy(p RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.RetryPolicy = &p
+	}
+}
+
+// RateLimit describes the throttling headers Azure DevOps sends
+// See: https://docs.microsoft.com/en-us/azure/devops/integrate/concepts/rate-limits
+type RateLimit struct {
+	// Resource is the throttled resource, taken from X-RateLimit-Resource
+	Resource string
+	// Limit is the total number of TSTUs allowed, from X-RateLimit-Limit
+	Limit int
+	// Remaining is the number of TSTUs left, from X-RateLimit-Remaining
+	Remaining int
+	// Reset is when usage returns to zero, from X-RateLimit-Reset
+	Reset time.Time
+	// Delay is how long the request was delayed, from X-RateLimit-Delay
+	Delay time.Duration
+	// RetryAfter is how long to wait before the next request, from Retry-After
+	RetryAfter time.Duration
+}
+
+// Throttled reports whether any of the rate limit headers were present
+func (r RateLimit) Throttled() bool {
+	return r.Resource != "" || r.Delay > 0 || r.RetryAfter > 0
+}
+
+// ParseRateLimit reads the rate limit headers from response. Headers that are
+// missing or malformed are left as zero values
+func ParseRateLimit(response *http.Response) RateLimit {
+	var r RateLimit
+	if response == nil {
+		return r
+	}
+
+	h := response.Header
+	r.Resource = h.Get("X-RateLimit-Resource")
+	r.Limit, _ = strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	r.Remaining, _ = strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		r.Reset = time.Unix(reset, 0)
+	}
+	if delay, err := strconv.ParseFloat(h.Get("X-RateLimit-Delay"), 64); err == nil {
+		r.Delay = time.Duration(delay * float64(time.Second))
+	}
+	r.RetryAfter = parseRetryAfter(h.Get("Retry-After"))
+
+	return r
+}
+
+// parseRetryAfter handles both forms of the Retry-After header, a number of
+// seconds or an HTTP date
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// rateLimits remembers the last rate limit seen by the Client
+type rateLimits struct {
+	sync.Mutex
+	last RateLimit
+}
+
+func (r *rateLimits) set(rl RateLimit) {
+	r.Lock()
+	r.last = rl
+	r.Unlock()
+}
+
+func (r *rateLimits) get() RateLimit {
+	r.Lock()
+	defer r.Unlock()
+	return r.last
+}
+
+// isRetryableStatus reports whether status is one Azure DevOps uses for
+// throttling or a temporary outage
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isIdempotent reports whether sending a request with method twice has the
+// same effect as sending it once
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// canRetry reports whether request may be sent again under p
+func (p *RetryPolicy) canRetry(request *http.Request) bool {
+	if p == nil || p.MaxAttempts < 2 {
+		return false
+	}
+	if !isIdempotent(request.Method) && !p.RetryNonIdempotent {
+		return false
+	}
+	// A request with a body can only be replayed if it can be rewound
+	return request.Body == nil || request.Body == http.NoBody || request.GetBody != nil
+}
+
+// backoff returns how long to wait before the given retry attempt, starting
+// at 1. The server's requested delay wins over the computed one
+func (p *RetryPolicy) backoff(attempt int, rl RateLimit) time.Duration {
+	wait := rl.RetryAfter
+	if rl.Delay > wait {
+		wait = rl.Delay
+	}
+
+	if wait == 0 && p.MinBackoff > 0 {
+		wait = p.MinBackoff << uint(attempt-1)
+		if wait <= 0 {
+			wait = p.MaxBackoff
+		}
+		// Add jitter, so a fleet of workers does not retry in lockstep
+		wait = wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+	}
+
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+	return wait
+}
+
+// do sends request, retrying under the Client's RetryPolicy. The returned
+// response is the final one and its body is left open for the caller
+func (c *Client) do(request *http.Request) (*http.Response, error) {
+	policy := c.RetryPolicy
+	retry := policy.canRetry(request)
+
+	for attempt := 1; ; attempt++ {
+		response, err := c.doer().Do(request)
+		if err != nil {
+			return nil, err
+		}
+
+		// A response the cache served without contacting the API says
+		// nothing about the current rate limit
+		rl := ParseRateLimit(response)
+		if response.Header.Get(cacheHeader) != "1" {
+			c.rateLimits.set(rl)
+		}
+
+		if !retry || attempt >= policy.MaxAttempts || !isRetryableStatus(response.StatusCode) {
+			return response, nil
+		}
+
+		response.Body.Close()
+
+		if err := sleep(request.Context(), policy.backoff(attempt, rl)); err != nil {
+			return nil, err
+		}
+
+		if request.GetBody != nil {
+			body, err := request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			request.Body = body
+		}
+	}
+}
+
+// sleep waits for d, returning early with the context's error if ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
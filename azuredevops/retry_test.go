@@ -0,0 +1,140 @@
+package azuredevops_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/benmatselby/go-azuredevops/azuredevops"
+)
+
+func TestClient_Retry(t *testing.T) {
+	tt := []struct {
+		name      string
+		method    string
+		failures  int
+		policy    *azuredevops.RetryPolicy
+		attempts  int
+		wantError bool
+	}{
+		{name: "retries a throttled GET until it succeeds", method: "GET", failures: 2, policy: &azuredevops.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}, attempts: 3},
+		{name: "gives up after max attempts", method: "GET", failures: 5, policy: &azuredevops.RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}, attempts: 2, wantError: true},
+		{name: "does not retry without a policy", method: "GET", failures: 1, policy: nil, attempts: 1, wantError: true},
+		{name: "does not retry a POST by default", method: "POST", failures: 1, policy: &azuredevops.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}, attempts: 1, wantError: true},
+		{name: "retries a POST when allowed", method: "POST", failures: 1, policy: &azuredevops.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, RetryNonIdempotent: true}, attempts: 2},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c, mux, _, teardown := setup()
+			defer teardown()
+			c.RetryPolicy = tc.policy
+
+			attempts := 0
+			mux.HandleFunc("/AZURE_DEVOPS_Project/_apis/build/builds", func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, tc.method)
+				attempts++
+				if tc.method == "POST" {
+					testBody(t, r, `{"id":1}`+"\n")
+				}
+				if attempts <= tc.failures {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				fmt.Fprint(w, `{"id": 1}`)
+			})
+
+			request, err := c.NewRequest(tc.method, "_apis/build/builds", map[string]int{"id": 1})
+			if tc.method == "GET" {
+				request, err = c.NewRequest(tc.method, "_apis/build/builds", nil)
+			}
+			if err != nil {
+				t.Fatalf("returned error: %v", err)
+			}
+
+			_, err = c.Execute(request, nil)
+			if tc.wantError != (err != nil) {
+				t.Fatalf("expected error to be %v, got %v", tc.wantError, err)
+			}
+
+			if tc.wantError && !azuredevops.IsTooManyRequests(err) {
+				t.Fatalf("expected a 429 error, got %v", err)
+			}
+
+			if attempts != tc.attempts {
+				t.Fatalf("expected %d attempts, got %d", tc.attempts, attempts)
+			}
+		})
+	}
+}
+
+func TestClient_Retry_HonoursContext(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+	c.RetryPolicy = &azuredevops.RetryPolicy{MaxAttempts: 3, MaxBackoff: time.Minute}
+
+	mux.HandleFunc("/AZURE_DEVOPS_Project/_apis/build/builds", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, _, err := c.Builds.List(ctx, nil)
+	if err != context.DeadlineExceeded {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestParseRateLimit(t *testing.T) {
+	response := &http.Response{Header: http.Header{}}
+	response.Header.Set("X-RateLimit-Resource", "ATCPU")
+	response.Header.Set("X-RateLimit-Limit", "200")
+	response.Header.Set("X-RateLimit-Remaining", "12")
+	response.Header.Set("X-RateLimit-Reset", "1609459200")
+	response.Header.Set("X-RateLimit-Delay", "1.5")
+	response.Header.Set("Retry-After", "30")
+
+	rl := azuredevops.ParseRateLimit(response)
+
+	if rl.Resource != "ATCPU" {
+		t.Fatalf("expected resource ATCPU, got %s", rl.Resource)
+	}
+	if rl.Limit != 200 || rl.Remaining != 12 {
+		t.Fatalf("expected limit 200 and remaining 12, got %d and %d", rl.Limit, rl.Remaining)
+	}
+	if !rl.Reset.Equal(time.Unix(1609459200, 0)) {
+		t.Fatalf("expected reset %v, got %v", time.Unix(1609459200, 0), rl.Reset)
+	}
+	if rl.Delay != 1500*time.Millisecond {
+		t.Fatalf("expected delay 1.5s, got %v", rl.Delay)
+	}
+	if rl.RetryAfter != 30*time.Second {
+		t.Fatalf("expected retry after 30s, got %v", rl.RetryAfter)
+	}
+	if !rl.Throttled() {
+		t.Fatalf("expected the response to be reported as throttled")
+	}
+}
+
+func TestClient_RateLimit(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/AZURE_DEVOPS_Project/_apis/build/builds", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		fmt.Fprint(w, "{}")
+	})
+
+	if _, _, err := c.Builds.List(context.Background(), nil); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if c.RateLimit().Remaining != 42 {
+		t.Fatalf("expected remaining 42, got %d", c.RateLimit().Remaining)
+	}
+}
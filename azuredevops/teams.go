@@ -1,6 +1,10 @@
 package azuredevops
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
 
 // TeamsService handles communication with the teams methods on the API
 // utilising https://docs.microsoft.com/en-us/rest/api/vsts/core/teams/get%20all%20teams
@@ -30,11 +34,11 @@ type TeamsListOptions struct {
 }
 
 // List returns list of the teams
-func (s *TeamsService) List(opts *TeamsListOptions) ([]Team, int, error) {
-	URL := fmt.Sprintf("/_apis/teams?api-version=6.1-preview.3")
+func (s *TeamsService) List(ctx context.Context, opts *TeamsListOptions) ([]Team, int, error) {
+	URL := fmt.Sprintf("/_apis/teams?api-version=%s", s.client.APIVersion(TeamsAPI))
 	URL, err := addOptions(URL, opts)
 
-	request, err := s.client.NewBaseRequest("GET", URL, nil)
+	request, err := s.client.NewBaseRequestWithContext(ctx, "GET", URL, nil)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -43,3 +47,38 @@ func (s *TeamsService) List(opts *TeamsListOptions) ([]Team, int, error) {
 
 	return response.Teams, response.Count, err
 }
+
+// Pager returns a Pager that walks every page of teams matching opts using
+// $top and $skip. The pager token is the $skip offset of the next page
+func (s *TeamsService) Pager(opts *TeamsListOptions) *Pager[Team] {
+	return NewPager(s.listPage(opts))
+}
+
+// ListAll returns every team matching opts, one page at a time
+func (s *TeamsService) ListAll(ctx context.Context, opts *TeamsListOptions) ([]Team, error) {
+	return ListAll(ctx, s.listPage(opts))
+}
+
+func (s *TeamsService) listPage(opts *TeamsListOptions) PageFunc[Team] {
+	return func(ctx context.Context, token string) ([]Team, string, error) {
+		pageOpts := TeamsListOptions{}
+		if opts != nil {
+			pageOpts = *opts
+		}
+		if token == "" {
+			token = strconv.Itoa(pageOpts.Skip)
+		}
+		skip, size, err := skipPage(token, pageOpts.Top)
+		if err != nil {
+			return nil, "", err
+		}
+		pageOpts.Skip = skip
+		pageOpts.Top = size
+
+		teams, _, err := s.List(ctx, &pageOpts)
+		if err != nil {
+			return nil, "", err
+		}
+		return teams, nextSkip(skip, size, len(teams)), nil
+	}
+}
@@ -1,6 +1,7 @@
 package azuredevops_test
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"testing"
@@ -55,7 +56,7 @@ func TestTeamsService_List(t *testing.T) {
 			})
 
 			opt := &azuredevops.TeamsListOptions{}
-			response, count, err := c.Teams.List(opt)
+			response, count, err := c.Teams.List(context.Background(), opt)
 			if err != nil {
 				t.Fatalf("returned error: %v", err)
 			}
@@ -76,3 +77,46 @@ func TestTeamsService_List(t *testing.T) {
 		})
 	}
 }
+
+func TestTeamsService_ListAll(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(teamsListURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.URL.Query().Get("$top") != "2" {
+			t.Fatalf("expected $top to be 2, got %s", r.URL.Query().Get("$top"))
+		}
+		switch r.URL.Query().Get("$skip") {
+		case "":
+			fmt.Fprint(w, `{"value": [{"name": "one"}, {"name": "two"}], "count": 2}`)
+		case "2":
+			fmt.Fprint(w, `{"value": [{"name": "three"}], "count": 1}`)
+		default:
+			t.Fatalf("unexpected $skip %s", r.URL.Query().Get("$skip"))
+		}
+	})
+
+	pager := c.Teams.Pager(&azuredevops.TeamsListOptions{Top: 2})
+
+	var names []string
+	pages := 0
+	for pager.More() {
+		teams, err := pager.NextPage(context.Background())
+		if err != nil {
+			t.Fatalf("returned error: %v", err)
+		}
+		pages++
+		for _, team := range teams {
+			names = append(names, team.Name)
+		}
+	}
+
+	if pages != 2 {
+		t.Fatalf("expected 2 pages; got %d", pages)
+	}
+
+	if len(names) != 3 || names[2] != "three" {
+		t.Fatalf("expected three teams; got %v", names)
+	}
+}
@@ -1,8 +1,9 @@
 package azuredevops
 
 import (
+	"context"
 	"fmt"
-	"time"
+	"strconv"
 )
 
 // TestsService handles communication with the Tests methods on the API
@@ -27,28 +28,129 @@ type Test struct {
 		ID          string `json:"id"`
 		DisplayName string `json:"displayName"`
 	} `json:"owner,omitempty"`
-	StartedDate   string `json:"startedDate"`
-	CompletedDate string `json:"completedDate"`
-	State         string `json:"state"`
+	StartedDate   Time         `json:"startedDate"`
+	CompletedDate Time         `json:"completedDate"`
+	State         TestRunState `json:"state"`
 	Plan          *struct {
 		ID string `json:"id"`
 	} `json:"plan,omitempty"`
 	Revision int `json:"revision"`
 }
 
+// TestRunState is enum type for the state of a test run
+type TestRunState string
+
+const (
+	// TestRunStateUnspecified is a test run with no state
+	TestRunStateUnspecified TestRunState = "Unspecified"
+	// TestRunStateNotStarted is a test run that has not started
+	TestRunStateNotStarted TestRunState = "NotStarted"
+	// TestRunStateInProgress is a test run that is running
+	TestRunStateInProgress TestRunState = "InProgress"
+	// TestRunStateCompleted is a test run that has finished
+	TestRunStateCompleted TestRunState = "Completed"
+	// TestRunStateAborted is a test run that was aborted
+	TestRunStateAborted TestRunState = "Aborted"
+	// TestRunStateWaiting is a test run waiting to start
+	TestRunStateWaiting TestRunState = "Waiting"
+	// TestRunStateNeedsInvestigation is a test run flagged for investigation
+	TestRunStateNeedsInvestigation TestRunState = "NeedsInvestigation"
+)
+
+var testRunStates = []TestRunState{
+	TestRunStateUnspecified, TestRunStateNotStarted, TestRunStateInProgress, TestRunStateCompleted,
+	TestRunStateAborted, TestRunStateWaiting, TestRunStateNeedsInvestigation,
+}
+
+// String returns the state as the API spells it
+func (s TestRunState) String() string {
+	return string(s)
+}
+
+// Valid reports whether s is a known test run state
+func (s TestRunState) Valid() bool {
+	return validEnum(s, testRunStates)
+}
+
+// UnmarshalJSON decodes a test run state, ignoring case
+func (s *TestRunState) UnmarshalJSON(data []byte) (err error) {
+	*s, err = unmarshalEnum(data, testRunStates)
+	return err
+}
+
+// TestOutcome is enum type for the outcome of a test result
+type TestOutcome string
+
+const (
+	// TestOutcomeUnspecified is a result with no outcome
+	TestOutcomeUnspecified TestOutcome = "Unspecified"
+	// TestOutcomeNone is a result that has not run
+	TestOutcomeNone TestOutcome = "None"
+	// TestOutcomePassed is a test that passed
+	TestOutcomePassed TestOutcome = "Passed"
+	// TestOutcomeFailed is a test that failed
+	TestOutcomeFailed TestOutcome = "Failed"
+	// TestOutcomeInconclusive is a test that was inconclusive
+	TestOutcomeInconclusive TestOutcome = "Inconclusive"
+	// TestOutcomeTimeout is a test that timed out
+	TestOutcomeTimeout TestOutcome = "Timeout"
+	// TestOutcomeAborted is a test that was aborted
+	TestOutcomeAborted TestOutcome = "Aborted"
+	// TestOutcomeBlocked is a test that was blocked
+	TestOutcomeBlocked TestOutcome = "Blocked"
+	// TestOutcomeNotExecuted is a test that was not executed
+	TestOutcomeNotExecuted TestOutcome = "NotExecuted"
+	// TestOutcomeWarning is a test that passed with warnings
+	TestOutcomeWarning TestOutcome = "Warning"
+	// TestOutcomeError is a test that errored
+	TestOutcomeError TestOutcome = "Error"
+	// TestOutcomeNotApplicable is a test that does not apply
+	TestOutcomeNotApplicable TestOutcome = "NotApplicable"
+	// TestOutcomePaused is a test that was paused
+	TestOutcomePaused TestOutcome = "Paused"
+	// TestOutcomeInProgress is a test that is running
+	TestOutcomeInProgress TestOutcome = "InProgress"
+	// TestOutcomeNotImpacted is a test skipped by test impact analysis
+	TestOutcomeNotImpacted TestOutcome = "NotImpacted"
+)
+
+var testOutcomes = []TestOutcome{
+	TestOutcomeUnspecified, TestOutcomeNone, TestOutcomePassed, TestOutcomeFailed,
+	TestOutcomeInconclusive, TestOutcomeTimeout, TestOutcomeAborted, TestOutcomeBlocked,
+	TestOutcomeNotExecuted, TestOutcomeWarning, TestOutcomeError, TestOutcomeNotApplicable,
+	TestOutcomePaused, TestOutcomeInProgress, TestOutcomeNotImpacted,
+}
+
+// String returns the outcome as the API spells it
+func (o TestOutcome) String() string {
+	return string(o)
+}
+
+// Valid reports whether o is a known test outcome
+func (o TestOutcome) Valid() bool {
+	return validEnum(o, testOutcomes)
+}
+
+// UnmarshalJSON decodes a test outcome, ignoring case
+func (o *TestOutcome) UnmarshalJSON(data []byte) (err error) {
+	*o, err = unmarshalEnum(data, testOutcomes)
+	return err
+}
+
 // TestsListOptions describes what the request to the API should look like
 type TestsListOptions struct {
 	Count    int    `url:"$top,omitempty"`
+	Skip     int    `url:"$skip,omitempty"`
 	BuildURI string `url:"buildUri,omitempty"`
 }
 
 // List returns list of the tests
 // utilising https://docs.microsoft.com/en-gb/rest/api/vsts/test/runs/list
-func (s *TestsService) List(opts *TestsListOptions) ([]Test, error) {
-	URL := fmt.Sprintf("_apis/test/runs?api-version=4.1")
+func (s *TestsService) List(ctx context.Context, opts *TestsListOptions) ([]Test, error) {
+	URL := fmt.Sprintf("_apis/test/runs?api-version=%s", s.client.APIVersion(TestRunsAPI))
 	URL, err := addOptions(URL, opts)
 
-	request, err := s.client.NewRequest("GET", URL, nil)
+	request, err := s.client.NewRequestWithContext(ctx, "GET", URL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -58,6 +160,41 @@ func (s *TestsService) List(opts *TestsListOptions) ([]Test, error) {
 	return response.Tests, err
 }
 
+// Pager returns a Pager that walks every page of tests matching opts using
+// $top and $skip. The pager token is the $skip offset of the next page
+func (s *TestsService) Pager(opts *TestsListOptions) *Pager[Test] {
+	return NewPager(s.listPage(opts))
+}
+
+// ListAll returns every test matching opts, one page at a time
+func (s *TestsService) ListAll(ctx context.Context, opts *TestsListOptions) ([]Test, error) {
+	return ListAll(ctx, s.listPage(opts))
+}
+
+func (s *TestsService) listPage(opts *TestsListOptions) PageFunc[Test] {
+	return func(ctx context.Context, token string) ([]Test, string, error) {
+		pageOpts := TestsListOptions{}
+		if opts != nil {
+			pageOpts = *opts
+		}
+		if token == "" {
+			token = strconv.Itoa(pageOpts.Skip)
+		}
+		skip, size, err := skipPage(token, pageOpts.Count)
+		if err != nil {
+			return nil, "", err
+		}
+		pageOpts.Skip = skip
+		pageOpts.Count = size
+
+		tests, err := s.List(ctx, &pageOpts)
+		if err != nil {
+			return nil, "", err
+		}
+		return tests, nextSkip(skip, size, len(tests)), nil
+	}
+}
+
 // TestResultsListResponse is the wrapper around the main response for the List of Tests
 type TestResultsListResponse struct {
 	Results []TestResult `json:"value"`
@@ -71,11 +208,11 @@ type TestResult struct {
 		Name string `json:"name"`
 		URL  string `json:"url"`
 	} `json:"project"`
-	StartedDate   time.Time `json:"startedDate"`
-	CompletedDate time.Time `json:"completedDate"`
-	DurationInMs  float64   `json:"durationInMs"`
-	Outcome       string    `json:"outcome"`
-	Revision      int       `json:"revision"`
+	StartedDate   Time        `json:"startedDate"`
+	CompletedDate Time        `json:"completedDate"`
+	DurationInMs  float64     `json:"durationInMs"`
+	Outcome       TestOutcome `json:"outcome"`
+	Revision      int         `json:"revision"`
 	RunBy         struct {
 		ID          string `json:"id"`
 		DisplayName string `json:"displayName"`
@@ -93,7 +230,7 @@ type TestResult struct {
 		Name string `json:"name"`
 		URL  string `json:"url"`
 	} `json:"testRun"`
-	LastUpdatedDate time.Time `json:"lastUpdatedDate"`
+	LastUpdatedDate Time `json:"lastUpdatedDate"`
 	LastUpdatedBy   struct {
 		ID          string `json:"id"`
 		DisplayName string `json:"displayName"`
@@ -108,13 +245,13 @@ type TestResult struct {
 		Name string `json:"name"`
 		URL  string `json:"url"`
 	} `json:"build"`
-	CreatedDate          time.Time `json:"createdDate"`
-	URL                  string    `json:"url"`
-	FailureType          string    `json:"failureType"`
-	AutomatedTestStorage string    `json:"automatedTestStorage"`
-	AutomatedTestType    string    `json:"automatedTestType"`
-	AutomatedTestTypeID  string    `json:"automatedTestTypeId"`
-	AutomatedTestID      string    `json:"automatedTestId"`
+	CreatedDate          Time   `json:"createdDate"`
+	URL                  string `json:"url"`
+	FailureType          string `json:"failureType"`
+	AutomatedTestStorage string `json:"automatedTestStorage"`
+	AutomatedTestType    string `json:"automatedTestType"`
+	AutomatedTestTypeID  string `json:"automatedTestTypeId"`
+	AutomatedTestID      string `json:"automatedTestId"`
 	Area                 struct {
 		ID   string `json:"id"`
 		Name string `json:"name"`
@@ -134,12 +271,12 @@ type TestResultsListOptions struct {
 
 // ResultsList returns list of the test results
 // utilising https://docs.microsoft.com/en-gb/rest/api/vsts/test/runs/list
-func (s *TestsService) ResultsList(opts *TestResultsListOptions) ([]TestResult, error) {
-	URL := fmt.Sprintf("_apis/test/Runs/%s/results?api-version=4.1", opts.RunID)
+func (s *TestsService) ResultsList(ctx context.Context, opts *TestResultsListOptions) ([]TestResult, error) {
+	URL := fmt.Sprintf("_apis/test/Runs/%s/results?api-version=%s", opts.RunID, s.client.APIVersion(TestResultsAPI))
 	opts.RunID = ""
 	URL, err := addOptions(URL, opts)
 
-	request, err := s.client.NewRequest("GET", URL, nil)
+	request, err := s.client.NewRequestWithContext(ctx, "GET", URL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package azuredevops_test
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"testing"
@@ -126,10 +127,10 @@ func TestTestsService_List(t *testing.T) {
 		response string
 		count    int
 		index    int
-		state    string
+		state    azuredevops.TestRunState
 		revision int
 	}{
-		{name: "return two tests", URL: testListURL, response: testListResponse, count: 2, index: 0, state: "Completed", revision: 4},
+		{name: "return two tests", URL: testListURL, response: testListResponse, count: 2, index: 0, state: azuredevops.TestRunStateCompleted, revision: 4},
 		{name: "can handle no builds returned", URL: testListURL, response: "{}", count: 0, index: -1},
 	}
 
@@ -145,7 +146,7 @@ func TestTestsService_List(t *testing.T) {
 			})
 
 			options := &azuredevops.TestsListOptions{}
-			tests, err := c.Tests.List(options)
+			tests, err := c.Tests.List(context.Background(), options)
 			if err != nil {
 				t.Fatalf("returned error: %v", err)
 			}
@@ -174,10 +175,10 @@ func TestTestsService_ResultsList(t *testing.T) {
 		response string
 		count    int
 		index    int
-		outcome  string
+		outcome  azuredevops.TestOutcome
 		testcase string
 	}{
-		{name: "return one result", URL: testResultsListURL, response: testResultsListResponse, count: 1, index: 0, outcome: "Passed", testcase: "Pass1"},
+		{name: "return one result", URL: testResultsListURL, response: testResultsListResponse, count: 1, index: 0, outcome: azuredevops.TestOutcomePassed, testcase: "Pass1"},
 		{name: "can handle no results returned", URL: testResultsListURL, response: "{}", count: 0, index: -1},
 	}
 
@@ -193,7 +194,7 @@ func TestTestsService_ResultsList(t *testing.T) {
 			})
 
 			options := &azuredevops.TestResultsListOptions{RunID: "1"}
-			tests, err := c.Tests.ResultsList(options)
+			tests, err := c.Tests.ResultsList(context.Background(), options)
 			if err != nil {
 				t.Fatalf("returned error: %v", err)
 			}
@@ -214,3 +215,39 @@ func TestTestsService_ResultsList(t *testing.T) {
 		})
 	}
 }
+
+func TestTestsService_ListAll(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	var skips []string
+	mux.HandleFunc(testListURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.URL.Query().Get("$top") != "2" {
+			t.Fatalf("expected $top to be 2, got %s", r.URL.Query().Get("$top"))
+		}
+		skip := r.URL.Query().Get("$skip")
+		skips = append(skips, skip)
+		switch skip {
+		case "":
+			fmt.Fprint(w, `{"value": [{"id": 1}, {"id": 2}]}`)
+		case "2":
+			fmt.Fprint(w, `{"value": [{"id": 3}]}`)
+		default:
+			t.Fatalf("unexpected $skip %s", skip)
+		}
+	})
+
+	tests, err := c.Tests.ListAll(context.Background(), &azuredevops.TestsListOptions{Count: 2})
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if fmt.Sprint(skips) != "[ 2]" {
+		t.Fatalf("expected the offset to advance by a page; got %q", skips)
+	}
+
+	if len(tests) != 3 || tests[2].ID != 3 {
+		t.Fatalf("expected three tests; got %v", tests)
+	}
+}
@@ -0,0 +1,75 @@
+package azuredevops
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Time is a time.Time that decodes the date formats Azure DevOps uses.
+// Missing and zero dates, such as 0001-01-01T00:00:00, decode to the zero
+// Time, and dates without a zone are taken to be UTC. omitempty has no
+// effect on a struct, so types sent in requests use *Time to leave unset
+// dates out
+type Time struct {
+	time.Time
+}
+
+// timeLayouts are tried in order when decoding a Time
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+// NewTime wraps t as a Time
+func NewTime(t time.Time) Time {
+	return Time{Time: t}
+}
+
+// ParseTime parses value in any of the formats Azure DevOps uses
+func ParseTime(value string) (Time, error) {
+	if value == "" {
+		return Time{}, nil
+	}
+
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			if t.Year() <= 1 {
+				return Time{}, nil
+			}
+			return Time{Time: t}, nil
+		}
+	}
+
+	return Time{}, fmt.Errorf("azuredevops: cannot parse %q as a time", value)
+}
+
+// UnmarshalJSON decodes a JSON string or null into t
+func (t *Time) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*t = Time{}
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	parsed, err := ParseTime(value)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// MarshalJSON encodes t as an RFC 3339 string, or null if t is zero
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.Time.Format(time.RFC3339Nano))
+}
@@ -0,0 +1,79 @@
+package azuredevops_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/benmatselby/go-azuredevops/azuredevops"
+)
+
+func TestTime_UnmarshalJSON(t *testing.T) {
+	tt := []struct {
+		name     string
+		input    string
+		expected time.Time
+	}{
+		{name: "utc", input: `"2018-04-30T12:01:02Z"`, expected: time.Date(2018, 4, 30, 12, 1, 2, 0, time.UTC)},
+		{name: "fractional seconds", input: `"2016-11-01T16:30:31.6655471Z"`, expected: time.Date(2016, 11, 1, 16, 30, 31, 665547100, time.UTC)},
+		{name: "offset", input: `"2018-05-04T01:00:00+01:00"`, expected: time.Date(2018, 5, 4, 0, 0, 0, 0, time.UTC)},
+		{name: "no zone", input: `"2018-05-04T00:00:00"`, expected: time.Date(2018, 5, 4, 0, 0, 0, 0, time.UTC)},
+		{name: "date only", input: `"2018-05-04"`, expected: time.Date(2018, 5, 4, 0, 0, 0, 0, time.UTC)},
+		{name: "zero date", input: `"0001-01-01T00:00:00"`},
+		{name: "zero date with zone", input: `"0001-01-01T00:00:00Z"`},
+		{name: "empty", input: `""`},
+		{name: "null", input: `null`},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var got azuredevops.Time
+			if err := json.Unmarshal([]byte(tc.input), &got); err != nil {
+				t.Fatalf("returned error: %v", err)
+			}
+
+			if !got.Equal(tc.expected) || got.IsZero() != tc.expected.IsZero() {
+				t.Fatalf("expected %v; got %v", tc.expected, got.Time)
+			}
+		})
+	}
+}
+
+func TestTime_UnmarshalJSONInvalid(t *testing.T) {
+	for _, input := range []string{`"yesterday"`, `42`} {
+		var got azuredevops.Time
+		if err := json.Unmarshal([]byte(input), &got); err == nil {
+			t.Fatalf("expected an error for %s, did not get one", input)
+		}
+	}
+}
+
+func TestTime_RoundTrip(t *testing.T) {
+	queued := azuredevops.NewTime(time.Date(2019, 2, 3, 4, 5, 6, 7000, time.UTC))
+	build := azuredevops.Build{QueueTime: &queued}
+
+	data, err := json.Marshal(build)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	for _, field := range []string{`"startTime"`, `"finishTime"`, `"createdDate"`} {
+		if strings.Contains(string(data), field) {
+			t.Fatalf("expected unset %s to be left out; got %s", field, data)
+		}
+	}
+
+	var got azuredevops.Build
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if got.QueueTime == nil || !got.QueueTime.Equal(queued.Time) {
+		t.Fatalf("expected queue time %v; got %v", queued, got.QueueTime)
+	}
+
+	if got.StartTime != nil || got.FinishTime != nil {
+		t.Fatalf("expected unset times to stay nil; got %v and %v", got.StartTime, got.FinishTime)
+	}
+}
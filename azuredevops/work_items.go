@@ -1,6 +1,7 @@
 package azuredevops
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -59,35 +60,65 @@ type WorkItemFields struct {
 	TagList     []string
 }
 
+// workItemFields are the fields fetched for each work item, matching
+// WorkItemFields
+// https://docs.microsoft.com/en-us/rest/api/vsts/wit/work%20item%20types%20field/list
+var workItemFields = []string{
+	"System.Id", "System.Title", "System.State", "System.WorkItemType",
+	"Microsoft.VSTS.Scheduling.StoryPoints", "System.BoardColumn",
+	"System.CreatedBy", "System.AssignedTo", "System.Tags",
+}
+
+// maxWorkItemIDs is the most work items the API returns in one request
+const maxWorkItemIDs = 200
+
 // GetForIteration will get a list of work items based on an iteration name
 // utilising https://docs.microsoft.com/en-gb/rest/api/vsts/wit/work%20items/list
-func (s *WorkItemsService) GetForIteration(team string, iteration Iteration) ([]WorkItem, error) {
-	queryIds, err := s.GetIdsForIteration(team, iteration)
+func (s *WorkItemsService) GetForIteration(ctx context.Context, team string, iteration Iteration) ([]WorkItem, error) {
+	queryIds, err := s.GetIdsForIteration(ctx, team, iteration)
 	if err != nil {
 		return nil, err
 	}
 
-	var workIds []string
-	for index := 0; index < len(queryIds); index++ {
-		workIds = append(workIds, strconv.Itoa(queryIds[index]))
+	return s.GetByIDs(ctx, queryIds)
+}
+
+// GetByIDs will get the work items with the given ids, with the same fields
+// as GetForIteration. Large lists are fetched in batches of 200, and work
+// items that are deleted or can't be read are left out
+// utilising https://docs.microsoft.com/en-gb/rest/api/vsts/wit/work%20items/list
+func (s *WorkItemsService) GetByIDs(ctx context.Context, ids []int) ([]WorkItem, error) {
+	var workItems []WorkItem
+	for start := 0; start < len(ids); start += maxWorkItemIDs {
+		end := start + maxWorkItemIDs
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		batch, err := s.getByIDs(ctx, ids[start:end])
+		workItems = append(workItems, batch...)
+		if err != nil {
+			return workItems, err
+		}
 	}
 
-	// https://docs.microsoft.com/en-us/rest/api/vsts/wit/work%20item%20types%20field/list
-	fields := []string{
-		"System.Id", "System.Title", "System.State", "System.WorkItemType",
-		"Microsoft.VSTS.Scheduling.StoryPoints", "System.BoardColumn",
-		"System.CreatedBy", "System.AssignedTo", "System.Tags",
+	return workItems, nil
+}
+
+func (s *WorkItemsService) getByIDs(ctx context.Context, ids []int) ([]WorkItem, error) {
+	var workIds []string
+	for index := 0; index < len(ids); index++ {
+		workIds = append(workIds, strconv.Itoa(ids[index]))
 	}
 
-	// Now we want to pad out the fields for the work items
 	URL := fmt.Sprintf(
-		"/_apis/wit/workitems?ids=%s&fields=%s&api-version=%s",
+		"/_apis/wit/workitems?ids=%s&fields=%s&errorPolicy=omit&api-version=%s",
 		strings.Join(workIds, ","),
-		strings.Join(fields, ","),
-		"6.1-preview.3",
+		strings.Join(workItemFields, ","),
+		s.client.APIVersion(WorkItemsAPI),
 	)
 
-	request, err := s.client.NewRequest("GET", URL, nil)
+	request, err := s.client.NewRequestWithContext(ctx, "GET", URL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -95,24 +126,31 @@ func (s *WorkItemsService) GetForIteration(team string, iteration Iteration) ([]
 	var response WorkItemListResponse
 	_, err = s.client.Execute(request, &response)
 
-	for index := range response.WorkItems {
-		response.WorkItems[index].Fields.TagList = strings.Split(response.WorkItems[index].Fields.Tags, "; ")
+	// Work items that are deleted or can't be read come back as null, which
+	// decodes to a work item without an id
+	var workItems []WorkItem
+	for _, workItem := range response.WorkItems {
+		if workItem.ID == 0 {
+			continue
+		}
+		workItem.Fields.TagList = strings.Split(workItem.Fields.Tags, "; ")
+		workItems = append(workItems, workItem)
 	}
 
-	return response.WorkItems, err
+	return workItems, err
 }
 
 // GetIdsForIteration will return an array of ids for a given iteration
 // utilising https://docs.microsoft.com/en-gb/rest/api/vsts/work/iterations/get%20iteration%20work%20items
-func (s *WorkItemsService) GetIdsForIteration(team string, iteration Iteration) ([]int, error) {
+func (s *WorkItemsService) GetIdsForIteration(ctx context.Context, team string, iteration Iteration) ([]int, error) {
 	URL := fmt.Sprintf(
 		"/%s/_apis/work/teamsettings/iterations/%s/workitems?api-version=%s",
 		url.PathEscape(team),
 		iteration.ID,
-		"6.1-preview.1",
+		s.client.APIVersion(IterationWorkItemsAPI),
 	)
 
-	request, err := s.client.NewRequest("GET", URL, nil)
+	request, err := s.client.NewRequestWithContext(ctx, "GET", URL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1,9 +1,11 @@
 package azuredevops_test
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/benmatselby/go-azuredevops/azuredevops"
@@ -184,7 +186,7 @@ func TestWorkItems_GetForIteration(t *testing.T) {
 			idsBaseURL:        getIdsURL,
 			actualIdsURL:      "/AZURE_DEVOPS_Project/AZURE_DEVOPS_TEAM/_apis/work/teamsettings/iterations/1/workitems?api-version=6.1-preview.1",
 			getBaseURL:        getURL,
-			actualGetURL:      "/AZURE_DEVOPS_Project/_apis/wit/workitems?ids=1,3&fields=System.Id,System.Title,System.State,System.WorkItemType,Microsoft.VSTS.Scheduling.StoryPoints,System.BoardColumn,System.CreatedBy,System.AssignedTo,System.Tags&api-version=6.1-preview.3",
+			actualGetURL:      "/AZURE_DEVOPS_Project/_apis/wit/workitems?ids=1,3&fields=System.Id,System.Title,System.State,System.WorkItemType,Microsoft.VSTS.Scheduling.StoryPoints,System.BoardColumn,System.CreatedBy,System.AssignedTo,System.Tags&errorPolicy=omit&api-version=6.1-preview.3",
 			idsResponse:       getIdsResponse,
 			getResponse:       getResponse,
 			expectedWorkItems: 3,
@@ -212,7 +214,7 @@ func TestWorkItems_GetForIteration(t *testing.T) {
 			})
 
 			iteration := azuredevops.Iteration{ID: "1"}
-			workItems, err := c.WorkItems.GetForIteration("AZURE_DEVOPS_TEAM", iteration)
+			workItems, err := c.WorkItems.GetForIteration(context.Background(), "AZURE_DEVOPS_TEAM", iteration)
 			if err != nil {
 				t.Fatalf("returned error: %v", err)
 			}
@@ -231,3 +233,95 @@ func TestWorkItems_GetForIteration(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkItems_GetForIteration_Cassette(t *testing.T) {
+	c := cassetteClient(t, "work_items_for_iteration")
+
+	team := cassetteEnv("AZURE_DEVOPS_TEAM", "Fabrikam-Fiber Team")
+	iteration := azuredevops.Iteration{ID: cassetteEnv("AZURE_DEVOPS_ITERATION_ID", "a589a806-bf11-4d4f-a031-c19813331553")}
+
+	workItems, err := c.WorkItems.GetForIteration(context.Background(), team, iteration)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(workItems) == 0 {
+		t.Fatalf("expected work items in the iteration")
+	}
+
+	for _, item := range workItems {
+		if item.ID != item.Fields.ID {
+			t.Fatalf("expected System.Id %d to match the work item id %d", item.Fields.ID, item.ID)
+		}
+		if item.Fields.Title == "" || item.Fields.Type == "" || item.Fields.CreatedBy.DisplayName == "" {
+			t.Fatalf("expected title, type and created by to be decoded; got %+v", item.Fields)
+		}
+	}
+
+	if workItems[0].Fields.Points != 8 || !reflect.DeepEqual(workItems[0].Fields.TagList, []string{"Login", "Security"}) {
+		t.Fatalf("expected points and tags to be decoded; got %+v", workItems[0].Fields)
+	}
+}
+
+func TestWorkItems_GetByIDs(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	var batches []int
+	mux.HandleFunc(getURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		ids := strings.Split(r.URL.Query().Get("ids"), ",")
+		batches = append(batches, len(ids))
+
+		var items []string
+		for _, id := range ids {
+			items = append(items, fmt.Sprintf(`{"id": %s, "fields": {"System.Id": %s}}`, id, id))
+		}
+		fmt.Fprintf(w, `{"count": %d, "value": [%s]}`, len(items), strings.Join(items, ","))
+	})
+
+	var ids []int
+	for id := 1; id <= 450; id++ {
+		ids = append(ids, id)
+	}
+
+	workItems, err := c.WorkItems.GetByIDs(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(workItems) != 450 || workItems[449].ID != 450 {
+		t.Fatalf("expected all 450 work items in order; got %d", len(workItems))
+	}
+
+	if !reflect.DeepEqual(batches, []int{200, 200, 50}) {
+		t.Fatalf("expected batches of 200; got %v", batches)
+	}
+
+	workItems, err = c.WorkItems.GetByIDs(context.Background(), nil)
+	if err != nil || len(workItems) != 0 || len(batches) != 3 {
+		t.Fatalf("expected no request for no ids; got %d items, %v", len(workItems), err)
+	}
+}
+
+func TestWorkItems_GetByIDsOmitsMissing(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(getURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if policy := r.URL.Query().Get("errorPolicy"); policy != "omit" {
+			t.Errorf("expected errorPolicy omit; got %q", policy)
+		}
+		fmt.Fprint(w, `{"count": 3, "value": [{"id": 1, "fields": {"System.Id": 1}}, null, {"id": 3, "fields": {"System.Id": 3}}]}`)
+	})
+
+	workItems, err := c.WorkItems.GetByIDs(context.Background(), []int{1, 2, 3})
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	if len(workItems) != 2 || workItems[0].ID != 1 || workItems[1].ID != 3 {
+		t.Fatalf("expected work items 1 and 3 without the deleted one; got %+v", workItems)
+	}
+}
@@ -1,5 +1,5 @@
 module github.com/benmatselby/go-azuredevops
 
-go 1.16
+go 1.21
 
 require github.com/google/go-querystring v1.0.0